@@ -1,10 +1,12 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	instanceStateMachine "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/state_machine/instance"
-	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
@@ -43,6 +45,8 @@ type App struct {
 	aws domain.IAws
 	// S3
 	s3 domain.IS3
+	// Http Client
+	httpClient domain.IHttpClient
 	// Tracer
 	tracer domain.ITracer
 	// Meter
@@ -53,6 +57,12 @@ type App struct {
 	stateMachine instanceStateMachine.IStateMachineService
 	// Services
 	services []domain.IService
+	// Start Order of the services
+	startOrder []domain.IService
+	// Mutex
+	mux sync.Mutex
+	// Ready closed when every service is started
+	ready chan struct{}
 	// Additional Config Type
 	additionalConfigType interface{}
 	// Started
@@ -79,11 +89,16 @@ func (a *App) Name() string {
 	return a.config.Name
 }
 
-// Start starts the app lib
+// Start starts the app lib and blocks until a TERM signal is received
 func (a *App) Start() (err error) {
-	exit := make(chan os.Signal, 1)
-	signal.Notify(exit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	defer cancel()
 
+	return a.Run(ctx)
+}
+
+// Run starts the app lib and blocks until the context is done or a service fails, stopping the app afterward
+func (a *App) Run(ctx context.Context) (err error) {
 	if a.config == nil {
 		a.config = &appConfig.Config{}
 		a.config.AdditionalConfig = a.additionalConfigType
@@ -94,38 +109,132 @@ func (a *App) Start() (err error) {
 		}
 	}
 
-	for _, s := range a.services {
-		if err = s.Start(); err != nil {
-			message.ErrorMessage(s.Name(), err)
-			_ = a.Stop() // stop previous started services
-			return err
-		} else {
-			message.StartMessage(s.Name())
-		}
+	// the services load the overlay configurations of the app environment
+	if a.config.Env != "" {
+		config.SetEnvironment(a.config.Env)
+	}
+
+	// log the keys of the reloaded configuration files
+	removeListener := config.DefaultLoader().OnChange(func(file string, changedKeys []string) {
+		message.Message(a.config.Name, fmt.Sprintf("Configuration file [%s] reloaded, changed keys: %s",
+			file, strings.Join(changedKeys, ", ")))
+	})
+	defer removeListener()
+
+	if err = a.startServices(); err != nil {
+		return err
 	}
 
 	a.started = true
 	message.StartMessage(fmt.Sprintf("Service [%s]", a.config.Name))
+	a.setReady()
 
-	// wait for a TERM signal
-	<-exit
+	// wait for the context or a service failure
+	if err = a.wait(ctx); err != nil {
+		_ = a.Stop()
+		return err
+	}
 
 	return a.Stop()
 }
 
-// Stop stops the app services
+// Ready gets a channel that is closed when every service is started
+func (a *App) Ready() <-chan struct{} {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	if a.ready == nil {
+		a.ready = make(chan struct{})
+	}
+	return a.ready
+}
+
+// setReady notifies that every service is started
+func (a *App) setReady() {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	if a.ready == nil {
+		a.ready = make(chan struct{})
+	}
+
+	select {
+	case <-a.ready:
+	default:
+		close(a.ready)
+	}
+}
+
+// wait waits for the context or the first service failure
+func (a *App) wait(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	failed := make(chan error, 1)
+	for _, s := range a.services {
+		notifier, ok := s.(domain.IServiceFailures)
+		if !ok {
+			continue
+		}
+
+		go func(s domain.IService, failures <-chan error) {
+			select {
+			case err, ok := <-failures:
+				if !ok || err == nil {
+					return
+				}
+				message.ErrorMessage(s.Name(), err)
+				select {
+				case failed <- err:
+				default:
+				}
+			case <-ctx.Done():
+			}
+		}(s, notifier.Failed())
+	}
+
+	select {
+	case err := <-failed:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// Stop stops the app services phase by phase, each one within its deadline
 func (a *App) Stop() (err error) {
 	var errStop error
+	order := a.stopOrder()
+
+	for _, phase := range shutdownPhases {
+		timeout := a.shutdownTimeout(phase)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+		for _, s := range phaseOrder(order, phase) {
+			if phase == domain.ShutdownPhaseDrain && shutdownPhase(s) != phase && s.Started() {
+				if drainer, ok := s.(domain.IDrainer); ok {
+					message.StopMessage(s.Name())
+					if err = drainer.Drain(ctx); err != nil {
+						errStop = err
+						message.ErrorMessage(s.Name(), err)
+					}
+					continue
+				}
+			}
+
+			if shutdownPhase(s) != phase || !s.Started() {
+				continue
+			}
 
-	for i := len(a.services) - 1; i >= 0; i-- {
-		if a.services[i].Started() {
-			message.StopMessage(a.services[i].Name())
-			if err = a.services[i].Stop(); err != nil {
+			message.StopMessage(s.Name())
+			if err = a.stopService(ctx, s, timeout); err != nil {
 				// stop every service ignoring the returned errors
 				errStop = err
-				message.ErrorMessage(a.services[i].Name(), err)
+				message.ErrorMessage(s.Name(), err)
 			}
 		}
+
+		cancel()
 	}
 
 	a.started = false
@@ -253,6 +362,18 @@ func (a *App) Aws() domain.IAws {
 	return a.aws
 }
 
+// WithHttpClient sets the http client service
+func (a *App) WithHttpClient(httpClient domain.IHttpClient) domain.IApp {
+	a.addService(httpClient)
+	a.httpClient = httpClient
+	return a
+}
+
+// HttpClient gets the http client service
+func (a *App) HttpClient() domain.IHttpClient {
+	return a.httpClient
+}
+
 // WithS3 sets the s3 service
 func (a *App) WithS3(s3 domain.IS3) domain.IApp {
 	a.addService(s3)
@@ -325,6 +446,32 @@ func (a *App) addService(service domain.IService) {
 	}
 }
 
+// WithService sets a custom service, that is started and stopped with the built-in ones
+func (a *App) WithService(service domain.IService) domain.IApp {
+	a.addService(service)
+	return a
+}
+
+// Services gets the registered services
+func (a *App) Services() []domain.IService {
+	return a.services
+}
+
+// ServiceOf gets the first registered service of the type T
+func ServiceOf[T any](a domain.IApp) (service T, ok bool) {
+	if a == nil {
+		return service, false
+	}
+
+	for _, s := range a.Services() {
+		if service, ok = s.(T); ok {
+			return service, true
+		}
+	}
+
+	return service, false
+}
+
 // ConfigFile gets the configuration file name
 func (a *App) ConfigFile() string {
 	return configFile
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+
 	appConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/app/config"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	instanceStateMachine "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/state_machine/instance"
@@ -32,6 +34,21 @@ func (a *AppMock) Stop() error {
 	return args.Error(0)
 }
 
+// Run starts the app lib until the context is done
+func (a *AppMock) Run(ctx context.Context) error {
+	args := a.Called(ctx)
+	return args.Error(0)
+}
+
+// Ready gets a channel that is closed when every service is started
+func (a *AppMock) Ready() <-chan struct{} {
+	args := a.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(<-chan struct{})
+}
+
 // WithLogger sets the logger service
 func (a *AppMock) WithLogger(logger domain.ILogger) domain.IApp {
 	args := a.Called(logger)
@@ -211,6 +228,24 @@ func (a *AppMock) Aws() domain.IAws {
 	return args.Get(0).(domain.IAws)
 }
 
+// WithHttpClient sets the http client service
+func (a *AppMock) WithHttpClient(httpClient domain.IHttpClient) domain.IApp {
+	args := a.Called(httpClient)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(domain.IApp)
+}
+
+// HttpClient gets the http client service
+func (a *AppMock) HttpClient() domain.IHttpClient {
+	args := a.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(domain.IHttpClient)
+}
+
 // WithS3 sets the s3 service
 func (a *AppMock) WithS3(s3 domain.IS3) domain.IApp {
 	args := a.Called(s3)
@@ -330,3 +365,21 @@ func (a *AppMock) Started() bool {
 	args := a.Called()
 	return args.Get(0).(bool)
 }
+
+// WithService sets a custom service
+func (a *AppMock) WithService(service domain.IService) domain.IApp {
+	args := a.Called(service)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(domain.IApp)
+}
+
+// Services gets the registered services
+func (a *AppMock) Services() []domain.IService {
+	args := a.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]domain.IService)
+}
@@ -5,7 +5,26 @@ type Config struct {
 	// Environment
 	Env string `yaml:"env"`
 	// Name
-	Name string `yaml:"name"`
+	Name string `yaml:"name" validate:"required"`
+	// Start Timeout Seconds of each service (0 disables the timeout)
+	StartTimeoutSeconds int `yaml:"startTimeoutSeconds" validate:"min=0"`
+	// Services Start Timeout Seconds overrides the start timeout by service key, which is the Key of the services
+	// that have one, otherwise the config file without extension (http, grpc, redis, database, ...) or the name
+	ServicesStartTimeoutSeconds map[string]int `yaml:"servicesStartTimeoutSeconds"`
+	// Shutdown
+	Shutdown ShutdownConfig `yaml:"shutdown"`
 	// Additional Config
 	AdditionalConfig interface{} `yaml:"additionalConfig"`
 }
+
+// ShutdownConfig deadlines of each shutdown phase
+type ShutdownConfig struct {
+	// Traffic Timeout Seconds to stop accepting http/grpc traffic
+	TrafficTimeoutSeconds int `yaml:"trafficTimeoutSeconds" validate:"min=0"`
+	// Drain Timeout Seconds to finish the in-flight messages of the consumers
+	DrainTimeoutSeconds int `yaml:"drainTimeoutSeconds" validate:"min=0"`
+	// Flush Timeout Seconds to flush the tracer, meter and log writers
+	FlushTimeoutSeconds int `yaml:"flushTimeoutSeconds" validate:"min=0"`
+	// Close Timeout Seconds to close the remaining connections
+	CloseTimeoutSeconds int `yaml:"closeTimeoutSeconds" validate:"min=0"`
+}
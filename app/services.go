@@ -0,0 +1,256 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+)
+
+// serviceNode service of the dependency graph
+type serviceNode struct {
+	// Service
+	service domain.IService
+	// Dependencies
+	dependencies []*serviceNode
+	// Done closed when the start finishes
+	done chan struct{}
+	// Error
+	err error
+	// Skipped true if the service was not started due to a failure
+	skipped bool
+}
+
+// buildGraph builds the dependency graph of the registered services
+func (a *App) buildGraph() ([]*serviceNode, error) {
+	nodes := make(map[domain.IService]*serviceNode, len(a.services))
+	graph := make([]*serviceNode, 0, len(a.services))
+
+	for _, s := range a.services {
+		if _, ok := nodes[s]; ok {
+			continue
+		}
+		node := &serviceNode{
+			service: s,
+			done:    make(chan struct{}),
+		}
+		nodes[s] = node
+		graph = append(graph, node)
+	}
+
+	for _, node := range graph {
+		dependent, ok := node.service.(domain.IServiceDependencies)
+		if !ok {
+			continue
+		}
+
+		// dependencies that are not registered in the app are ignored
+		for _, dependency := range dependent.DependsOn() {
+			if dependencyNode, ok := nodes[dependency]; ok && dependencyNode != node {
+				node.dependencies = append(node.dependencies, dependencyNode)
+			}
+		}
+	}
+
+	if err := checkCycles(graph); err != nil {
+		return nil, err
+	}
+
+	return graph, nil
+}
+
+// checkCycles checks if the graph has dependency cycles
+func checkCycles(graph []*serviceNode) error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	state := make(map[*serviceNode]int, len(graph))
+	var path []string
+
+	var visit func(node *serviceNode) error
+	visit = func(node *serviceNode) error {
+		switch state[node] {
+		case visiting:
+			return errorCodes.ErrorServiceDependencyCycle().Formats(
+				strings.Join(append(path, node.service.Name()), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[node] = visiting
+		path = append(path, node.service.Name())
+		for _, dependency := range node.dependencies {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[node] = visited
+
+		return nil
+	}
+
+	for _, node := range graph {
+		if err := visit(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startServices starts the services concurrently, each one as soon as its dependencies are started.
+// If a service fails, the services that were already started are stopped
+func (a *App) startServices() error {
+	graph, err := a.buildGraph()
+	if err != nil {
+		message.ErrorMessage(a.config.Name, err)
+		return err
+	}
+
+	a.startOrder = nil
+	failed := make(chan struct{})
+	var failOnce sync.Once
+	var errStart error
+
+	wg := sync.WaitGroup{}
+	for _, node := range graph {
+		wg.Add(1)
+		go func(node *serviceNode) {
+			defer wg.Done()
+			defer close(node.done)
+
+			// wait for the dependencies
+			for _, dependency := range node.dependencies {
+				select {
+				case <-dependency.done:
+					if dependency.err != nil || dependency.skipped {
+						node.skipped = true
+						return
+					}
+				case <-failed:
+					node.skipped = true
+					return
+				}
+			}
+
+			// do not start new services after a failure
+			select {
+			case <-failed:
+				node.skipped = true
+				return
+			default:
+			}
+
+			if node.err = a.startService(node.service); node.err != nil {
+				message.ErrorMessage(node.service.Name(), node.err)
+				failOnce.Do(func() {
+					errStart = node.err
+					close(failed)
+				})
+				return
+			}
+
+			a.addStarted(node.service)
+			message.StartMessage(node.service.Name())
+		}(node)
+	}
+	wg.Wait()
+
+	if errStart != nil {
+		_ = a.Stop() // stop previous started services
+		return errStart
+	}
+
+	return nil
+}
+
+// startService starts a service within the configured timeout
+func (a *App) startService(service domain.IService) error {
+	timeout := a.startTimeout(service)
+	if timeout <= 0 {
+		return service.Start()
+	}
+
+	status := make(chan error, 1)
+	go func() {
+		status <- service.Start()
+	}()
+
+	select {
+	case err := <-status:
+		return err
+	case <-time.After(timeout):
+		return errorCodes.ErrorServiceStartTimeout().Formats(service.Name(), timeout)
+	}
+}
+
+// startTimeout gets the start timeout of a service
+func (a *App) startTimeout(service domain.IService) time.Duration {
+	seconds := a.config.StartTimeoutSeconds
+	if len(a.config.ServicesStartTimeoutSeconds) == 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	key := serviceKey(service)
+	for name, value := range a.config.ServicesStartTimeoutSeconds {
+		if strings.EqualFold(name, key) {
+			seconds = value
+			break
+		}
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// serviceKey gets the stable key of a service, since the names of some services change when they start:
+// the key of the service, the config file without extension, or the name
+func serviceKey(service domain.IService) string {
+	if keyed, ok := service.(domain.IServiceKey); ok {
+		return keyed.Key()
+	}
+
+	if configured, ok := service.(interface{ ConfigFile() string }); ok && configured.ConfigFile() != "" {
+		file := filepath.Base(configured.ConfigFile())
+		return strings.TrimSuffix(file, filepath.Ext(file))
+	}
+
+	return service.Name()
+}
+
+// addStarted adds a service to the start order
+func (a *App) addStarted(service domain.IService) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	a.startOrder = append(a.startOrder, service)
+}
+
+// stopOrder gets the services in the reverse order they were started,
+// followed by the remaining ones in the reverse order they were registered
+func (a *App) stopOrder() []domain.IService {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	order := make([]domain.IService, 0, len(a.services))
+	added := make(map[domain.IService]bool, len(a.services))
+
+	for i := len(a.startOrder) - 1; i >= 0; i-- {
+		order = append(order, a.startOrder[i])
+		added[a.startOrder[i]] = true
+	}
+
+	for i := len(a.services) - 1; i >= 0; i-- {
+		if !added[a.services[i]] {
+			order = append(order, a.services[i])
+			added[a.services[i]] = true
+		}
+	}
+
+	return order
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+)
+
+// Default shutdown phases deadlines
+const (
+	defaultTrafficTimeout = 10 * time.Second
+	defaultDrainTimeout   = 30 * time.Second
+	defaultFlushTimeout   = 5 * time.Second
+	defaultCloseTimeout   = 5 * time.Second
+)
+
+// shutdownPhases the shutdown phases by execution order
+var shutdownPhases = []domain.ShutdownPhase{
+	domain.ShutdownPhaseTraffic,
+	domain.ShutdownPhaseDrain,
+	domain.ShutdownPhaseFlush,
+	domain.ShutdownPhaseClose,
+}
+
+// shutdownPhase gets the shutdown phase of a service, closing it at the end by default
+func shutdownPhase(service domain.IService) domain.ShutdownPhase {
+	if graceful, ok := service.(domain.IGracefulService); ok {
+		return graceful.ShutdownPhase()
+	}
+	return domain.ShutdownPhaseClose
+}
+
+// phaseOrder gets the stop order of a phase. The services that were drained are closed at the end of the close phase,
+// since the other services may still publish their logs through them
+func phaseOrder(order []domain.IService, phase domain.ShutdownPhase) []domain.IService {
+	if phase != domain.ShutdownPhaseClose {
+		return order
+	}
+
+	sorted := make([]domain.IService, 0, len(order))
+	var drainers []domain.IService
+	for _, service := range order {
+		if _, ok := service.(domain.IDrainer); ok {
+			drainers = append(drainers, service)
+			continue
+		}
+		sorted = append(sorted, service)
+	}
+
+	return append(sorted, drainers...)
+}
+
+// shutdownTimeout gets the deadline of a shutdown phase
+func (a *App) shutdownTimeout(phase domain.ShutdownPhase) time.Duration {
+	var seconds int
+	var timeout time.Duration
+
+	switch phase {
+	case domain.ShutdownPhaseTraffic:
+		seconds, timeout = a.config.Shutdown.TrafficTimeoutSeconds, defaultTrafficTimeout
+	case domain.ShutdownPhaseDrain:
+		seconds, timeout = a.config.Shutdown.DrainTimeoutSeconds, defaultDrainTimeout
+	case domain.ShutdownPhaseFlush:
+		seconds, timeout = a.config.Shutdown.FlushTimeoutSeconds, defaultFlushTimeout
+	default:
+		seconds, timeout = a.config.Shutdown.CloseTimeoutSeconds, defaultCloseTimeout
+	}
+
+	if seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	return timeout
+}
+
+// stopService stops a service until the context is done
+func (a *App) stopService(ctx context.Context, service domain.IService, timeout time.Duration) error {
+	status := make(chan error, 1)
+	go func() {
+		if graceful, ok := service.(domain.IGracefulService); ok {
+			status <- graceful.Shutdown(ctx)
+			return
+		}
+		status <- service.Stop()
+	}()
+
+	select {
+	case err := <-status:
+		return err
+	case <-ctx.Done():
+		return errorCodes.ErrorServiceStopTimeout().Formats(service.Name(), timeout)
+	}
+}
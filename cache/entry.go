@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Entry response stored in the cache
+type Entry struct {
+	// Status
+	Status int `json:"status"`
+	// Header
+	Header http.Header `json:"header"`
+	// Body
+	Body []byte `json:"body"`
+	// ETag strong entity tag of the body
+	ETag string `json:"etag"`
+	// Stored At
+	StoredAt time.Time `json:"storedAt"`
+}
+
+// NewETag creates the strong entity tag of a body
+func NewETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// MatchETag checks if the if none match header matches an entity tag, with the weak comparison
+func MatchETag(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+)
+
+// IStore store of the cached responses
+type IStore interface {
+	// Get gets the entry of a key, nil when it is not cached
+	Get(ctx context.Context, key string) (*Entry, error)
+	// Set stores the entry of a key during the ttl, invalidated by the tags
+	Set(ctx context.Context, key string, entry *Entry, ttl time.Duration, tags ...string) error
+	// Invalidate removes the entries of the tags
+	Invalidate(ctx context.Context, tags ...string) error
+}
+
+// setScript stores an entry and adds its key to the sets of the tags, which expire after the last key.
+// The entry and the tags are stored together, so an invalidation never leaves an entry without its tags
+var setScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+for i = 2, #KEYS do
+	local created = redis.call('EXISTS', KEYS[i]) == 0
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if ttl > 0 then
+		local pttl = redis.call('PTTL', KEYS[i])
+		if created or (pttl >= 0 and pttl < ttl) then
+			redis.call('PEXPIRE', KEYS[i], ttl)
+		end
+	else
+		redis.call('PERSIST', KEYS[i])
+	end
+end
+return 1
+`)
+
+// invalidateScript removes the entries of the tags and the sets of the tags
+var invalidateScript = redis.NewScript(`
+for _, tag in ipairs(KEYS) do
+	local keys = redis.call('SMEMBERS', tag)
+	for _, key in ipairs(keys) do
+		redis.call('DEL', key)
+	end
+	redis.call('DEL', tag)
+end
+return 1
+`)
+
+// RedisStore store that shares the cached responses between the instances in redis
+type RedisStore struct {
+	// Redis
+	redis domain.IRedis
+	// Prefix of the keys
+	prefix string
+}
+
+// NewRedisStore creates a new redis store
+func NewRedisStore(redis domain.IRedis, prefix string) *RedisStore {
+	return &RedisStore{
+		redis:  redis,
+		prefix: prefix,
+	}
+}
+
+// Get gets the entry of a key, nil when it is not cached
+func (s *RedisStore) Get(ctx context.Context, key string) (*Entry, error) {
+	data, err := s.redis.Client().Get(ctx, s.entryKey(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{}
+	if err = json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Set stores the entry of a key during the ttl, invalidated by the tags
+func (s *RedisStore) Set(ctx context.Context, key string, entry *Entry, ttl time.Duration, tags ...string) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	keys := append([]string{s.entryKey(key)}, s.tagKeys(tags)...)
+	return setScript.Run(ctx, s.redis.Client(), keys, data, ttl.Milliseconds()).Err()
+}
+
+// Invalidate removes the entries of the tags
+func (s *RedisStore) Invalidate(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return invalidateScript.Run(ctx, s.redis.Client(), s.tagKeys(tags)).Err()
+}
+
+// entryKey gets the redis key of an entry
+func (s *RedisStore) entryKey(key string) string {
+	return s.prefix + "entry:" + key
+}
+
+// tagKeys gets the redis keys of the tags
+func (s *RedisStore) tagKeys(tags []string) []string {
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, s.prefix+"tag:"+tag)
+	}
+	return keys
+}
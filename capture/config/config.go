@@ -0,0 +1,23 @@
+package config
+
+// Config capture configurations of the request and response bodies
+type Config struct {
+	// Max Bytes captured of each body, the remaining bytes are replaced by a truncation marker
+	MaxBytes int `yaml:"maxBytes" default:"65536" validate:"min=0"`
+	// Content Types captured, with wildcard subtypes (text/*)
+	ContentTypes []string `yaml:"contentTypes" default:"application/json,application/problem+json,application/xml,application/x-www-form-urlencoded,text/plain"`
+	// Routes that replace the captured content types
+	Routes []RouteConfig `yaml:"routes" validate:"dive"`
+}
+
+// RouteConfig capture configurations of a route
+type RouteConfig struct {
+	// Method, every method when empty
+	Method string `yaml:"method"`
+	// Path route template (/files/:id)
+	Path string `yaml:"path" validate:"required"`
+	// Content Types captured, with wildcard subtypes (text/*)
+	ContentTypes []string `yaml:"contentTypes"`
+	// Disabled captures nothing of the route
+	Disabled bool `yaml:"disabled"`
+}
@@ -0,0 +1,33 @@
+package capture
+
+import (
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	contextDomain "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/context"
+)
+
+// RequestBody gets the request body of a context that is captured for the logs and the traces,
+// truncated by the capture policy of the request, or nil when it is not captured.
+// The body of a context without a capture policy is captured as it is
+func RequestBody(ctx contextDomain.IContext) []byte {
+	if ctx == nil || ctx.Request() == nil {
+		return nil
+	}
+
+	body := ctx.GetBody()
+	if len(body) == 0 {
+		return nil
+	}
+
+	value, _ := ctx.Get(contextInfra.CtxCapture)
+	policy, ok := value.(*Policy)
+	if !ok {
+		return body
+	}
+
+	request := ctx.Request()
+	if !policy.Captures(request.Method, ctx.FullPath(), request.URL.Path, request.Header.Get("Content-Type")) {
+		return nil
+	}
+
+	return policy.Truncate(body)
+}
@@ -0,0 +1,139 @@
+package capture
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	captureConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture/config"
+)
+
+// binaryContentTypes content types that are never captured, since they are binary or multipart
+var binaryContentTypes = []string{
+	"multipart/*",
+	"image/*",
+	"audio/*",
+	"video/*",
+	"font/*",
+	"application/octet-stream",
+	"application/zip",
+	"application/gzip",
+	"application/pdf",
+	"application/grpc",
+}
+
+// Excluder consumer of the captured bodies, like the logs and the traces
+type Excluder interface {
+	// ExcludesBody checks if the bodies of an uri are not consumed
+	ExcludesBody(method, uri string) bool
+}
+
+// Policy decides which bodies are captured and how many bytes, shared by the logs and the traces
+type Policy struct {
+	// Configurations
+	config captureConfig.Config
+	// Excluders
+	excluders []Excluder
+}
+
+// NewPolicy creates a new capture policy, the bodies are captured unless every excluder excludes them
+func NewPolicy(config captureConfig.Config, excluders ...Excluder) *Policy {
+	policy := &Policy{
+		config: config,
+	}
+
+	for _, excluder := range excluders {
+		if excluder != nil {
+			policy.excluders = append(policy.excluders, excluder)
+		}
+	}
+
+	return policy
+}
+
+// Captures checks if the body of a route, with a content type, is captured
+func (p *Policy) Captures(method, route, path, contentType string) bool {
+	if p == nil || p.config.MaxBytes <= 0 {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || MatchContentType(binaryContentTypes, mediaType) {
+		return false
+	}
+
+	contentTypes := p.config.ContentTypes
+	for _, r := range p.config.Routes {
+		if r.Path == route && (r.Method == "" || strings.EqualFold(r.Method, method)) {
+			if r.Disabled {
+				return false
+			}
+			if len(r.ContentTypes) > 0 {
+				contentTypes = r.ContentTypes
+			}
+			break
+		}
+	}
+
+	return MatchContentType(contentTypes, mediaType) && !p.excluded(method, route, path)
+}
+
+// Buffers checks if a request body with a content type is buffered in the context, the multipart and binary bodies are streamed
+func (p *Policy) Buffers(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err != nil || !MatchContentType(binaryContentTypes, mediaType)
+}
+
+// MaxBytes gets the max bytes captured of each body
+func (p *Policy) MaxBytes() int {
+	if p == nil {
+		return 0
+	}
+	return p.config.MaxBytes
+}
+
+// Truncate truncates a body to the max bytes, with a truncation marker
+func (p *Policy) Truncate(body []byte) []byte {
+	if len(body) <= p.MaxBytes() {
+		return body
+	}
+	return Truncated(body[:p.MaxBytes()], len(body)-p.MaxBytes())
+}
+
+// Truncated appends the truncation marker of the omitted bytes to a body
+func Truncated(body []byte, omitted int) []byte {
+	if omitted <= 0 {
+		return body
+	}
+	truncated := make([]byte, 0, len(body)+32)
+	truncated = append(truncated, body...)
+	return append(truncated, fmt.Sprintf("...[truncated %d bytes]", omitted)...)
+}
+
+// excluded checks if every excluder excludes the bodies of the route or of the path
+func (p *Policy) excluded(method, route, path string) bool {
+	if len(p.excluders) == 0 {
+		return false
+	}
+
+	for _, excluder := range p.excluders {
+		if !excluder.ExcludesBody(method, route) && (path == route || !excluder.ExcludesBody(method, path)) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchContentType checks if a media type matches one of the content types, with wildcards (text/*, */*)
+func MatchContentType(contentTypes []string, mediaType string) bool {
+	for _, contentType := range contentTypes {
+		contentType = strings.ToLower(strings.TrimSpace(contentType))
+		if contentType == mediaType || contentType == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(contentType, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
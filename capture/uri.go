@@ -0,0 +1,25 @@
+package capture
+
+import "strings"
+
+// Uri uri of a request
+type Uri struct {
+	// Method
+	Method string `yaml:"method"`
+	// Uri
+	Uri string `yaml:"uri"`
+}
+
+// UriList list of uris
+type UriList []Uri
+
+// Contains checks if the list contains the method and the uri
+func (list UriList) Contains(method, uri string) bool {
+	for _, i := range list {
+		if strings.EqualFold(i.Method, method) &&
+			strings.EqualFold(i.Uri, uri) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,45 +1,67 @@
 package config
 
 import (
-	"os"
-	"path"
 	"path/filepath"
 	"strings"
-
-	"github.com/spf13/viper"
+	"sync"
+	"unicode"
 )
 
 const (
-	// basePath the base path for all the configurations
-	basePath = "conf/"
+	// defaultBasePath the default base path for all the configurations
+	defaultBasePath = "conf/"
+	// basePathVariable environment variable with the base path for all the configurations
+	basePathVariable = "APP_CONFIG_PATH"
+	// envVariable environment variable with the environment, used when it is not set by the app
+	envVariable = "APP_ENV"
+	// tagName tag with the configuration key of a field
+	tagName = "mapstructure"
 )
 
-// Load loads a configuration file from the path basePath to the obj struct
-func Load(file string, obj interface{}) (err error) {
-	viper.SetConfigFile(getCwd() + path.Join(basePath, file))
-
-	if err = viper.ReadInConfig(); err != nil {
-		return err
-	}
+var (
+	// mux protects the default loader
+	mux sync.RWMutex
+	// defaultLoader loader used by the services
+	defaultLoader = NewLoader()
+)
 
-	if err = viper.Unmarshal(obj); err != nil {
-		return err
-	}
+// SetDefaultLoader sets the loader used by the services, for example to load the configurations from an embed.FS
+func SetDefaultLoader(loader *Loader) {
+	mux.Lock()
+	defer mux.Unlock()
+	defaultLoader = loader
+}
 
-	return nil
+// DefaultLoader gets the loader used by the services
+func DefaultLoader() *Loader {
+	mux.RLock()
+	defer mux.RUnlock()
+	return defaultLoader
 }
 
-func getCwd() string {
-	var cwd string
+// SetEnvironment sets the environment of the overlay configurations of the default loader
+func SetEnvironment(env string) {
+	DefaultLoader().SetEnvironment(env)
+}
 
-	if isTestRunning() {
-		x, _ := filepath.Abs("./")
-		aDir := strings.Split(x, "/")
-		cwd = strings.Join(aDir[0:len(aDir)-4], "/") + "/"
-	}
+// Environment gets the environment of the overlay configurations of the default loader
+func Environment() string {
+	return DefaultLoader().Environment()
+}
 
-	return cwd
+// Load loads a configuration file to the obj struct with the default loader
+func Load(file string, obj interface{}) (err error) {
+	return DefaultLoader().Load(file, obj)
 }
-func isTestRunning() bool {
-	return strings.HasSuffix(os.Args[0], ".test") || strings.Contains(os.Args[0], "/_test/")
+
+// EnvPrefix gets the environment variables prefix of a configuration file, for example DATABASE for database.yaml
+func EnvPrefix(file string) string {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
 }
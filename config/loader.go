@@ -0,0 +1,257 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Loader loads configuration files, each one with its own viper instance
+type Loader struct {
+	// Base Path of the configuration files
+	basePath string
+	// File System of the configuration files, the os file system when nil
+	fileSystem fs.FS
+	// Environment of the overlay configurations
+	environment string
+	// Secret Resolvers by scheme
+	resolvers map[string]ISecretResolver
+	// Watcher of the configuration files
+	watcher *watcher
+	// Mutex
+	mux sync.RWMutex
+}
+
+// LoaderOption option of the loader
+type LoaderOption func(l *Loader)
+
+// WithBasePath sets the base path of the configuration files
+func WithBasePath(basePath string) LoaderOption {
+	return func(l *Loader) {
+		l.basePath = basePath
+	}
+}
+
+// WithFS sets the file system of the configuration files, for example an embed.FS
+func WithFS(fileSystem fs.FS) LoaderOption {
+	return func(l *Loader) {
+		l.fileSystem = fileSystem
+	}
+}
+
+// WithEnvironment sets the environment of the overlay configurations
+func WithEnvironment(env string) LoaderOption {
+	return func(l *Loader) {
+		l.environment = env
+	}
+}
+
+// WithResolver sets the secret resolver of a scheme
+func WithResolver(scheme string, resolver ISecretResolver) LoaderOption {
+	return func(l *Loader) {
+		l.resolvers[strings.ToLower(scheme)] = resolver
+	}
+}
+
+// NewLoader creates a new loader. The base path is read from the APP_CONFIG_PATH environment variable,
+// falling back to conf/, unless the WithBasePath option is given
+func NewLoader(opts ...LoaderOption) *Loader {
+	l := &Loader{
+		basePath: os.Getenv(basePathVariable),
+		resolvers: map[string]ISecretResolver{
+			EnvScheme:  EnvResolver(),
+			FileScheme: FileResolver(),
+		},
+	}
+
+	if l.basePath == "" {
+		l.basePath = defaultBasePath
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// BasePath gets the base path of the configuration files
+func (l *Loader) BasePath() string {
+	return l.basePath
+}
+
+// RegisterResolver registers the secret resolver of a scheme
+func (l *Loader) RegisterResolver(scheme string, resolver ISecretResolver) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.resolvers[strings.ToLower(scheme)] = resolver
+}
+
+// SetEnvironment sets the environment of the overlay configurations
+func (l *Loader) SetEnvironment(env string) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.environment = env
+}
+
+// Environment gets the environment of the overlay configurations, falling back to the APP_ENV environment variable
+func (l *Loader) Environment() string {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	if l.environment != "" {
+		return l.environment
+	}
+	return os.Getenv(envVariable)
+}
+
+// Load loads a configuration file from the base path to the obj struct.
+// The configuration is layered, each layer overriding the previous one:
+//  1. the base file <base path>/<file>
+//  2. the environment file <base path>/<env>/<file>, if it exists
+//  3. the environment variables <FILE>_<KEY>, for example DATABASE_MASTER_PASSWORD for master.password of database.yaml
+//
+// The secret references, like ${env:DB_PASS} or ${file:/run/secrets/db}, are resolved afterward.
+// At last, the default values are applied and the constraints are validated (see Validate)
+func (l *Loader) Load(file string, obj interface{}) (err error) {
+	v, err := l.read(file, obj)
+	if err != nil {
+		return err
+	}
+
+	if err = v.Unmarshal(obj); err != nil {
+		return err
+	}
+
+	return Validate(obj)
+}
+
+// read reads the layers of a configuration file
+func (l *Loader) read(file string, obj interface{}) (_ *viper.Viper, err error) {
+	v := viper.New()
+	v.SetConfigType(strings.TrimPrefix(filepath.Ext(file), "."))
+
+	content, err := l.readFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = v.ReadConfig(bytes.NewReader(content)); err != nil {
+		return nil, err
+	}
+
+	// environment overlay
+	if env := l.Environment(); env != "" {
+		content, err = l.readFile(path.Join(env, file))
+		switch {
+		case err == nil:
+			if err = v.MergeConfig(bytes.NewReader(content)); err != nil {
+				return nil, err
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+	}
+
+	// environment variables
+	v.SetEnvPrefix(EnvPrefix(file))
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	for _, key := range configKeys(v, obj) {
+		if err = v.BindEnv(key); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = l.resolveSecrets(context.Background(), v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// readFile reads a file from the base path
+func (l *Loader) readFile(file string) ([]byte, error) {
+	if l.fileSystem != nil {
+		return fs.ReadFile(l.fileSystem, path.Join(l.basePath, file))
+	}
+	return os.ReadFile(filepath.Join(l.basePath, file))
+}
+
+// configKeys gets the keys of the configuration files and of the struct fields, so that
+// the environment variables can override values that are missing in the files
+func configKeys(v *viper.Viper, obj interface{}) []string {
+	keys := v.AllKeys()
+	added := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		added[key] = true
+	}
+
+	for _, key := range structKeys("", reflect.ValueOf(obj)) {
+		if !added[key] {
+			keys = append(keys, key)
+			added[key] = true
+		}
+	}
+
+	return keys
+}
+
+// structKeys gets the keys of the fields of a struct, following pointers and interfaces
+func structKeys(prefix string, value reflect.Value) []string {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			if value.Kind() == reflect.Interface {
+				return nil
+			}
+			value = reflect.New(value.Type().Elem())
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct || value.Type() == reflect.TypeOf(time.Time{}) {
+		if prefix == "" {
+			return nil
+		}
+		return []string{prefix}
+	}
+
+	var keys []string
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get(tagName), ",")
+		if name == "-" {
+			continue
+		}
+
+		if options == "squash" {
+			keys = append(keys, structKeys(prefix, value.Field(i))...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		key := strings.ToLower(name)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		keys = append(keys, structKeys(key, value.Field(i))...)
+	}
+
+	return keys
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
+	"github.com/spf13/viper"
+)
+
+const (
+	// EnvScheme scheme of the environment variables references, for example ${env:DB_PASS}
+	EnvScheme = "env"
+	// FileScheme scheme of the file references, for example ${file:/run/secrets/db}
+	FileScheme = "file"
+)
+
+// referenceRegex matches the secret references ${<scheme>:<reference>}
+var referenceRegex = regexp.MustCompile(`\$\{([a-zA-Z][a-zA-Z0-9_-]*):([^}]+)}`)
+
+// ISecretResolver resolves the secret references of a scheme.
+// Other sources, like the aws secrets manager, are added by registering a resolver in the loader
+type ISecretResolver interface {
+	// Resolve gets the secret value of a reference
+	Resolve(ctx context.Context, reference string) (string, error)
+}
+
+// SecretResolverFunc function that resolves secret references
+type SecretResolverFunc func(ctx context.Context, reference string) (string, error)
+
+// Resolve gets the secret value of a reference
+func (f SecretResolverFunc) Resolve(ctx context.Context, reference string) (string, error) {
+	return f(ctx, reference)
+}
+
+// EnvResolver resolves the references to environment variables
+func EnvResolver() ISecretResolver {
+	return SecretResolverFunc(func(_ context.Context, reference string) (string, error) {
+		value, ok := os.LookupEnv(reference)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", reference)
+		}
+		return value, nil
+	})
+}
+
+// FileResolver resolves the references to files, like docker or kubernetes secrets
+func FileResolver() ISecretResolver {
+	return SecretResolverFunc(func(_ context.Context, reference string) (string, error) {
+		content, err := os.ReadFile(reference)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	})
+}
+
+// resolveSecrets replaces the secret references of the configuration values
+func (l *Loader) resolveSecrets(ctx context.Context, v *viper.Viper) error {
+	for _, key := range v.AllKeys() {
+		switch value := v.Get(key).(type) {
+		case string:
+			resolved, err := l.resolve(ctx, value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			if resolved != value {
+				v.Set(key, resolved)
+			}
+		case []interface{}:
+			changed := false
+			values := make([]interface{}, len(value))
+			for i, item := range value {
+				values[i] = item
+				if text, ok := item.(string); ok {
+					resolved, err := l.resolve(ctx, text)
+					if err != nil {
+						return fmt.Errorf("%s: %w", key, err)
+					}
+					values[i] = resolved
+					changed = changed || resolved != text
+				}
+			}
+			if changed {
+				v.Set(key, values)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolve replaces the secret references of a value
+func (l *Loader) resolve(ctx context.Context, value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var err error
+	resolved := referenceRegex.ReplaceAllStringFunc(value, func(match string) string {
+		if err != nil {
+			return match
+		}
+
+		groups := referenceRegex.FindStringSubmatch(match)
+		scheme, reference := strings.ToLower(groups[1]), groups[2]
+
+		l.mux.RLock()
+		resolver, ok := l.resolvers[scheme]
+		l.mux.RUnlock()
+		if !ok {
+			err = fmt.Errorf("unknown secret scheme %s", scheme)
+			return match
+		}
+
+		var secret string
+		if secret, err = resolver.Resolve(ctx, reference); err != nil {
+			err = fmt.Errorf("resolving %s secret %s: %w", scheme, reference, err)
+			return match
+		}
+
+		// the resolved secrets are never written in the messages
+		message.RegisterSecret(secret)
+		return secret
+	})
+
+	return resolved, err
+}
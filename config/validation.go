@@ -0,0 +1,232 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// defaultTagName tag with the default value of a field, applied when the field has the zero value
+	defaultTagName = "default"
+	// validateTagName tag with the constraints of a field
+	validateTagName = "validate"
+)
+
+var (
+	// validate validator of the configurations
+	validate     *validator.Validate
+	validateOnce sync.Once
+)
+
+// FieldError invalid field of a configuration
+type FieldError struct {
+	// Field
+	Field string
+	// Message
+	Message string
+}
+
+// ValidationErrors invalid fields of a configuration
+type ValidationErrors []FieldError
+
+// Error gets the description of every invalid field
+func (e ValidationErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for _, field := range e {
+		fields = append(fields, fmt.Sprintf("%s %s", field.Field, field.Message))
+	}
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(fields, "; "))
+}
+
+// Validate applies the default values and checks the constraints of a configuration,
+// returning every invalid field at once
+func Validate(obj interface{}) error {
+	if err := applyDefaults(reflect.ValueOf(obj)); err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	err := getValidator().Struct(value.Interface())
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	fieldErrors := make(ValidationErrors, 0, len(errs))
+	for _, e := range errs {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   fieldName(e.Namespace()),
+			Message: fieldMessage(e),
+		})
+	}
+
+	return fieldErrors
+}
+
+// getValidator gets the validator of the configurations, that names the fields with the yaml keys
+func getValidator() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+		validate.SetTagName(validateTagName)
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, tag := range []string{"yaml", "json", tagName} {
+				name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+				if name == "-" {
+					return ""
+				}
+				if name != "" {
+					return name
+				}
+			}
+			return field.Name
+		})
+	})
+
+	return validate
+}
+
+// fieldName gets the field name without the name of the root struct
+func fieldName(namespace string) string {
+	if _, name, ok := strings.Cut(namespace, "."); ok {
+		return name
+	}
+	return namespace
+}
+
+// fieldMessage gets the message of a constraint
+func fieldMessage(e validator.FieldError) string {
+	switch e.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		return "is required"
+	case "min", "gte":
+		return fmt.Sprintf("must be greater or equal than %s", e.Param())
+	case "max", "lte":
+		return fmt.Sprintf("must be lower or equal than %s", e.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", e.Param())
+	case "lt":
+		return fmt.Sprintf("must be lower than %s", e.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s]", e.Param())
+	default:
+		return fmt.Sprintf("failed the %s validation", e.Tag())
+	}
+}
+
+// applyDefaults sets the default values of the fields that have the zero value
+func applyDefaults(value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			return applyDefaults(value.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := applyDefaults(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			// the values of a map are only addressable through pointers
+			if iter.Value().Kind() == reflect.Ptr {
+				if err := applyDefaults(iter.Value()); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			fieldValue := value.Field(i)
+			if tag, ok := field.Tag.Lookup(defaultTagName); ok && fieldValue.CanSet() && fieldValue.IsZero() {
+				if err := setDefault(fieldValue, tag); err != nil {
+					return fmt.Errorf("invalid default value of %s: %w", field.Name, err)
+				}
+			}
+
+			if err := applyDefaults(fieldValue); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setDefault sets the default value of a field
+func setDefault(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(duration))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	case reflect.Slice:
+		items := strings.Split(value, ",")
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setDefault(slice.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,327 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
+	"github.com/spf13/viper"
+)
+
+const (
+	// watcherName name of the watcher in the messages
+	watcherName = "Config Watcher"
+	// reloadDelay waits for the writes of a file to finish before reloading it
+	reloadDelay = 100 * time.Millisecond
+)
+
+// watcher watches the configuration files of a loader
+type watcher struct {
+	// Watcher
+	*fsnotify.Watcher
+	// Files by name
+	files map[string]*watchedFile
+	// Listeners of the changes of every file
+	listeners map[int]func(file string, changedKeys []string)
+	// Next Id of the subscribers and listeners
+	nextId int
+	// Mutex
+	mux sync.Mutex
+}
+
+// watchedFile configuration file that is watched
+type watchedFile struct {
+	// Settings of the last load
+	settings map[string]interface{}
+	// Subscribers by id
+	subscribers map[int]*subscriber
+	// Timer of the reload
+	timer *time.Timer
+}
+
+// subscriber of the changes of a configuration file
+type subscriber struct {
+	// New Obj creates the configuration struct
+	newObj func() interface{}
+	// Callback
+	callback func(obj interface{}, changedKeys []string)
+}
+
+// Subscribe subscribes the changes of a configuration file of the default loader
+func Subscribe[T any](file string, callback func(cfg *T, changedKeys []string)) (unsubscribe func(), err error) {
+	return SubscribeLoader(DefaultLoader(), file, callback)
+}
+
+// SubscribeLoader subscribes the changes of a configuration file of a loader
+func SubscribeLoader[T any](l *Loader, file string, callback func(cfg *T, changedKeys []string)) (unsubscribe func(), err error) {
+	return l.Watch(file,
+		func() interface{} { return new(T) },
+		func(obj interface{}, changedKeys []string) { callback(obj.(*T), changedKeys) },
+	)
+}
+
+// OnChange adds a listener of the changes of every watched file
+func (l *Loader) OnChange(listener func(file string, changedKeys []string)) (remove func()) {
+	w := l.getWatcher()
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	id := w.nextId
+	w.nextId++
+	w.listeners[id] = listener
+
+	return func() {
+		w.mux.Lock()
+		defer w.mux.Unlock()
+		delete(w.listeners, id)
+	}
+}
+
+// Watch watches a configuration file, calling back with the reloaded configuration when its keys change.
+// The files of an fs source are not watched, since they can not change
+func (l *Loader) Watch(file string, newObj func() interface{}, callback func(obj interface{}, changedKeys []string)) (unwatch func(), err error) {
+	if l.fileSystem != nil {
+		return func() {}, nil
+	}
+
+	w := l.getWatcher()
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if w.Watcher == nil {
+		if w.Watcher, err = fsnotify.NewWatcher(); err != nil {
+			return nil, err
+		}
+		go l.watch(w, w.Watcher)
+	}
+
+	watched, ok := w.files[file]
+	if !ok {
+		v, err := l.read(file, newObj())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dir := range l.watchDirs(file) {
+			if err = w.Add(dir); err != nil {
+				return nil, err
+			}
+		}
+
+		watched = &watchedFile{
+			settings:    settings(v),
+			subscribers: make(map[int]*subscriber),
+		}
+		w.files[file] = watched
+	}
+
+	id := w.nextId
+	w.nextId++
+	watched.subscribers[id] = &subscriber{
+		newObj:   newObj,
+		callback: callback,
+	}
+
+	return func() {
+		w.mux.Lock()
+		defer w.mux.Unlock()
+
+		delete(watched.subscribers, id)
+		if len(watched.subscribers) > 0 {
+			return
+		}
+
+		delete(w.files, file)
+		if len(w.files) == 0 && w.Watcher != nil {
+			_ = w.Close()
+			w.Watcher = nil
+		}
+	}, nil
+}
+
+// getWatcher gets the watcher of the loader
+func (l *Loader) getWatcher() *watcher {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.watcher == nil {
+		l.watcher = &watcher{
+			files:     make(map[string]*watchedFile),
+			listeners: make(map[int]func(file string, changedKeys []string)),
+		}
+	}
+
+	return l.watcher
+}
+
+// watchDirs gets the directories of the base and of the environment files.
+// The directories are watched instead of the files, since editors and kubernetes replace the files
+func (l *Loader) watchDirs(file string) []string {
+	dirs := []string{filepath.Dir(filepath.Join(l.basePath, file))}
+
+	if env := l.Environment(); env != "" {
+		dir := filepath.Dir(filepath.Join(l.basePath, env, file))
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// watch handles the events of the watched directories
+func (l *Loader) watch(w *watcher, fsWatcher *fsnotify.Watcher) {
+	for {
+		select {
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return
+			}
+
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) &&
+				!event.Has(fsnotify.Rename) && !event.Has(fsnotify.Remove) {
+				continue
+			}
+
+			w.mux.Lock()
+			for file, watched := range w.files {
+				if !l.isFileEvent(file, event.Name) {
+					continue
+				}
+
+				file := file
+				if watched.timer != nil {
+					watched.timer.Stop()
+				}
+				watched.timer = time.AfterFunc(reloadDelay, func() { l.reload(w, file) })
+			}
+			w.mux.Unlock()
+
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			message.ErrorMessage(watcherName, err)
+		}
+	}
+}
+
+// isFileEvent checks if an event changes a configuration file
+func (l *Loader) isFileEvent(file string, name string) bool {
+	name = filepath.Clean(name)
+	paths := []string{filepath.Join(l.basePath, file)}
+	if env := l.Environment(); env != "" {
+		paths = append(paths, filepath.Join(l.basePath, env, file))
+	}
+
+	for _, p := range paths {
+		if name == filepath.Clean(p) {
+			return true
+		}
+
+		// kubernetes swaps the ..data symlink of the mounted directory
+		if filepath.Dir(name) == filepath.Dir(p) && strings.HasPrefix(filepath.Base(name), "..") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reload reloads a configuration file and calls back the subscribers if its keys changed
+func (l *Loader) reload(w *watcher, file string) {
+	w.mux.Lock()
+	watched, ok := w.files[file]
+	if !ok {
+		w.mux.Unlock()
+		return
+	}
+
+	subscribers := make([]*subscriber, 0, len(watched.subscribers))
+	for _, s := range watched.subscribers {
+		subscribers = append(subscribers, s)
+	}
+
+	listeners := make([]func(file string, changedKeys []string), 0, len(w.listeners))
+	for _, listener := range w.listeners {
+		listeners = append(listeners, listener)
+	}
+	previous := watched.settings
+	w.mux.Unlock()
+
+	if len(subscribers) == 0 {
+		return
+	}
+
+	v, err := l.read(file, subscribers[0].newObj())
+	if err != nil {
+		// the previous configuration is kept
+		message.ErrorMessage(watcherName, err)
+		return
+	}
+
+	current := settings(v)
+	changedKeys := diff(previous, current)
+	if len(changedKeys) == 0 {
+		return
+	}
+
+	w.mux.Lock()
+	watched.settings = current
+	w.mux.Unlock()
+
+	for _, listener := range listeners {
+		listener(file, changedKeys)
+	}
+
+	for _, s := range subscribers {
+		obj := s.newObj()
+		if err = v.Unmarshal(obj); err == nil {
+			err = Validate(obj)
+		}
+		if err != nil {
+			// the subscriber keeps the previous configuration
+			message.ErrorMessage(watcherName, err)
+			continue
+		}
+		s.callback(obj, changedKeys)
+	}
+}
+
+// settings gets the values of every key
+func settings(v *viper.Viper) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, key := range v.AllKeys() {
+		if value := v.Get(key); value != nil {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// diff gets the sorted keys whose values are different
+func diff(previous, current map[string]interface{}) []string {
+	var keys []string
+	for key, value := range current {
+		if !reflect.DeepEqual(previous[key], value) {
+			keys = append(keys, key)
+		}
+	}
+
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -6,4 +6,9 @@ const (
 	CtxPath         = "path"
 	CtxParams       = "params"
 	ContextGrpcKeys = "keys"
+	CtxApiKeyName   = "apiKeyName"
+	CtxSession      = "session"
+	CtxRequestId    = "requestId"
+	CtxTraceId      = "traceId"
+	CtxCapture      = "capture"
 )
@@ -67,6 +67,13 @@ func (c *Context) SetParams(params map[string]any) {
 	c.Context.Set(CtxParams, params)
 }
 
+func (c *Context) SetRequestId(id string) {
+	c.Context.Set(CtxRequestId, id)
+	if c.Context.Request != nil {
+		c.Context.Request = c.Context.Request.WithContext(WithRequestId(c.Context.Request.Context(), id))
+	}
+}
+
 func (c *Context) GetBody() []byte {
 	body, exists := c.Context.Get(CtxBody)
 	if exists {
@@ -81,6 +88,22 @@ func (c *Context) GetParams() map[string]any {
 	return c.Context.GetStringMap(CtxParams)
 }
 
+func (c *Context) RequestId() string {
+	if c == nil || c.Context == nil {
+		return ""
+	}
+
+	if id := c.Context.GetString(CtxRequestId); id != "" {
+		return id
+	}
+
+	if c.Context.Request != nil {
+		return RequestId(c.Context.Request.Context())
+	}
+
+	return ""
+}
+
 func (c *Context) GetMethod() string {
 	return c.Context.GetString(CtxMethod)
 }
@@ -125,22 +148,49 @@ func (c *Context) FromGrpc(ctx context.Context) contextDomain.IContext {
 		_ = json.Unmarshal([]byte(md.Get(ContextGrpcKeys)[0]), &gCtx.Keys)
 	}
 
-	return NewContext(gCtx)
+	newCtx := NewContext(gCtx)
+	if ids := md.Get(MetadataRequestId); len(ids) > 0 && ValidRequestId(ids[0]) {
+		newCtx.SetRequestId(ids[0])
+	} else if id := RequestId(ctx); id != "" {
+		newCtx.SetRequestId(id)
+	}
+
+	return newCtx
 }
 
 func (c *Context) ToGrpc() context.Context {
-	if c.Keys() == nil {
+	id := c.RequestId()
+	if c.Keys() == nil && id == "" {
 		return c
 	}
 	res := make(map[string]string)
-	b, _ := json.Marshal(c.Keys())
-	res[ContextGrpcKeys] = string(b)
+	if c.Keys() != nil {
+		b, _ := json.Marshal(c.Keys())
+		res[ContextGrpcKeys] = string(b)
+	}
+	if id != "" {
+		res[MetadataRequestId] = id
+	}
 	if c.Request() == nil {
 		return metadata.NewOutgoingContext(context.Background(), metadata.New(res))
 	}
 	return metadata.NewOutgoingContext(c.Request().Context(), metadata.New(res))
 }
 
+func (c *Context) Session() contextDomain.ISession {
+	if c == nil || c.Context == nil {
+		return nil
+	}
+
+	value, exists := c.Context.Get(CtxSession)
+	if !exists {
+		return nil
+	}
+
+	session, _ := value.(contextDomain.ISession)
+	return session
+}
+
 func (c *Context) RequestContext() context.Context {
 	if c.Request() != nil {
 		// return a ctx with gin and span data
@@ -529,3 +529,16 @@ func (c *ContextMock) RequestContext() context.Context {
 	args := c.Called()
 	return args.Get(0).(context.Context)
 }
+
+func (c *ContextMock) Session() contextDomain.ISession {
+	args := c.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(contextDomain.ISession)
+}
+
+func (c *ContextMock) RequestId() string {
+	args := c.Called()
+	return args.Get(0).(string)
+}
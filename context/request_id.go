@@ -0,0 +1,76 @@
+package context
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// HeaderRequestId header, message attribute and message header with the request id
+	HeaderRequestId = "X-Request-ID"
+	// MetadataRequestId grpc metadata with the request id
+	MetadataRequestId = "x-request-id"
+
+	// maxRequestIdLength maximum length of the received request ids
+	maxRequestIdLength = 128
+)
+
+// requestIdKey key of the request id in the contexts
+type requestIdKey struct{}
+
+// NewRequestId creates a new request id
+func NewRequestId() string {
+	return uuid.New().String()
+}
+
+// WithRequestId sets the request id of a context
+func WithRequestId(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, requestIdKey{}, id)
+}
+
+// RequestId gets the request id of a context, or of the keys of a gin context
+func RequestId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	if id, ok := ctx.Value(requestIdKey{}).(string); ok {
+		return id
+	}
+
+	id, _ := ctx.Value(CtxRequestId).(string)
+	return id
+}
+
+// ValidRequestId checks if a received request id can be used, to prevent the injection of values in the logs
+func ValidRequestId(id string) bool {
+	if id == "" || len(id) > maxRequestIdLength {
+		return false
+	}
+
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == ':') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewContextFrom creates a new context from the context of another transport (for example of a consumer),
+// keeping its request id
+func NewContextFrom(ctx context.Context) *Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return NewContext(&gin.Context{
+		Request: (&http.Request{}).WithContext(ctx),
+	})
+}
@@ -5,7 +5,7 @@ type Config struct {
 	// Multi Database
 	MultiDb bool `yaml:"multiDb"`
 	// Master
-	Master *Connection `yaml:"master"`
+	Master *Connection `yaml:"master" validate:"required"`
 	// Slave
 	Slave *Connection `yaml:"slave"`
 	// Migrations Disabled
@@ -17,15 +17,15 @@ type Config struct {
 // Connection has the connection configuration
 type Connection struct {
 	// Database
-	Database string `yaml:"database"`
+	Database string `yaml:"database" validate:"required"`
 	// Host
-	Host string `yaml:"host"`
+	Host string `yaml:"host" validate:"required"`
 	// User
-	User string `yaml:"user"`
+	User string `yaml:"user" validate:"required"`
 	// Password
 	Password string `yaml:"password"`
 	// Port
-	Port int `yaml:"port"`
+	Port int `yaml:"port" validate:"min=1,max=65535"`
 	// Driver
-	Driver string `yaml:"driver"`
+	Driver string `yaml:"driver" validate:"required"`
 }
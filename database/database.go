@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"github.com/gocraft/dbr/v2"
 
@@ -64,6 +65,11 @@ func (d *Database) Name() string {
 	return d.name
 }
 
+// DependsOn the migrations are traced
+func (d *Database) DependsOn() []domain.IService {
+	return []domain.IService{d.app.Tracer()}
+}
+
 // Start starts the database connection
 func (d *Database) Start() (err error) {
 	if d.config == nil {
@@ -122,6 +128,23 @@ func (d *Database) Write() session.ISession {
 	return d.client.DbWrite
 }
 
+// HealthCheck pings the write and read connections
+func (d *Database) HealthCheck(ctx context.Context) (err error) {
+	if d.client.DbWrite != nil {
+		if err = d.client.DbWrite.DB().PingContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	if d.client.DbRead != nil {
+		if err = d.client.DbRead.DB().PingContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // openConnection open a new connection
 func openConnection(config *databaseConfig.Connection, eventReceiver dbr.EventReceiver) (*session.Session, error) {
 	conn, err := dbr.Open(
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"context"
+
 	"github.com/guilhermealegre/go-clean-arch-core-lib/database/session"
 	databaseConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/database/config"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
@@ -70,3 +72,9 @@ func (d *DatabaseMock) Started() bool {
 	args := d.Called()
 	return args.Get(0).(bool)
 }
+
+// HealthCheck checks the service health
+func (d *DatabaseMock) HealthCheck(ctx context.Context) error {
+	args := d.Called(ctx)
+	return args.Error(0)
+}
@@ -47,6 +47,11 @@ func (d *Datatable) Name() string {
 	return d.name
 }
 
+// DependsOn the datatable searches on the database and elastic search
+func (d *Datatable) DependsOn() []domain.IService {
+	return []domain.IService{d.app.Database(), d.app.ElasticSearch()}
+}
+
 // Start starts the service
 func (d *Datatable) Start() error {
 	d.started = true
@@ -13,12 +13,25 @@ const (
 	NotApplicable = "n/a"
 )
 
+// Shutdown Phases, by execution order
+const (
+	// ShutdownPhaseTraffic stops accepting new traffic
+	ShutdownPhaseTraffic ShutdownPhase = iota
+	// ShutdownPhaseDrain lets the consumers finish their in-flight messages, also of the services that implement IDrainer
+	ShutdownPhaseDrain
+	// ShutdownPhaseFlush flushes the tracer, the meter and the log writers
+	ShutdownPhaseFlush
+	// ShutdownPhaseClose closes the remaining connections
+	ShutdownPhaseClose
+)
+
 // Sub Types
 const (
-	DefaultSubType  SubType = "service"
-	DatabaseSubType SubType = "database"
-	RabbitSubType   SubType = "rabbit"
-	ElasticSubType  SubType = "elastic"
-	RedisSubType    SubType = "redis"
-	SQSSubType      SubType = "sqs"
+	DefaultSubType    SubType = "service"
+	DatabaseSubType   SubType = "database"
+	RabbitSubType     SubType = "rabbit"
+	ElasticSubType    SubType = "elastic"
+	RedisSubType      SubType = "redis"
+	SQSSubType        SubType = "sqs"
+	HttpClientSubType SubType = "http_client"
 )
@@ -102,4 +102,28 @@ type IContext interface {
 	FromGrpc(ctx context.Context) IContext
 	ToGrpc() context.Context
 	RequestContext() context.Context
+	Session() ISession
+	RequestId() string
+}
+
+// ISession session of a request
+type ISession interface {
+	// Id gets the id
+	Id() string
+	// IsNew checks if the session was created in the request
+	IsNew() bool
+	// Get gets a value
+	Get(key string) (value any, exists bool)
+	// GetString gets a string value
+	GetString(key string) string
+	// Set sets a value
+	Set(key string, value any)
+	// Delete deletes a value
+	Delete(key string)
+	// Clear deletes every value
+	Clear()
+	// Regenerate changes the id, keeping the values (for example after a login)
+	Regenerate()
+	// Destroy deletes the session and expires its cookies
+	Destroy()
 }
@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"io"
+	"net/http"
 
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/go-playground/validator/v10"
@@ -30,6 +31,8 @@ import (
 	elasticSearchConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/elastic_search/config"
 	grpcConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/grpc/config"
 	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/openapi"
+	httpClientConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http_client/config"
 	loggerConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/logger/config"
 	rabbitmqConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/rabbitmq/config"
 	redisConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/redis/config"
@@ -44,6 +47,11 @@ import (
 type IApp interface {
 	IService
 
+	// Run starts the app and blocks until the context is done or a service fails, stopping the app afterward
+	Run(ctx context.Context) error
+	// Ready gets a channel that is closed when every service is started
+	Ready() <-chan struct{}
+
 	// Config gets the configuration
 	Config() *appConfig.Config
 	// ConfigFile the configuration file
@@ -101,6 +109,10 @@ type IApp interface {
 	WithDatatable(datatable IDatatable) IApp
 	// Datatable gets the datatable
 	Datatable() IDatatable
+	// WithHttpClient sets the http client
+	WithHttpClient(httpClient IHttpClient) IApp
+	// HttpClient gets the http client
+	HttpClient() IHttpClient
 	// WithAws sets the aws connection
 	WithS3(s3 IS3) IApp
 	// S3 gets the s3 Connection
@@ -111,6 +123,10 @@ type IApp interface {
 	StateMachine() stateMachineDomain.IStateMachineService
 	// WithAdditionalConfigType sets an additional config type
 	WithAdditionalConfigType(obj interface{}) IApp
+	// WithService sets a custom service
+	WithService(service IService) IApp
+	// Services gets the registered services
+	Services() []IService
 }
 
 // IService service interface
@@ -125,6 +141,45 @@ type IService interface {
 	Started() bool
 }
 
+// IServiceDependencies optional interface of the services that depend on other services
+type IServiceDependencies interface {
+	// DependsOn gets the services that must be started before this service
+	DependsOn() []IService
+}
+
+// IServiceKey optional interface of the services with a stable key, used in the configurations of the app
+type IServiceKey interface {
+	// Key gets the key of the service
+	Key() string
+}
+
+// IServiceFailures optional interface of the services that can fail after being started
+type IServiceFailures interface {
+	// Failed gets the channel where the fatal errors are sent after the start
+	Failed() <-chan error
+}
+
+// IGracefulService optional interface of the services that stop gracefully within a deadline
+type IGracefulService interface {
+	// ShutdownPhase gets the shutdown phase in which the service is stopped
+	ShutdownPhase() ShutdownPhase
+	// Shutdown stops the service until the context is done
+	Shutdown(ctx context.Context) error
+}
+
+// IDrainer optional interface of the services that stop consuming in the drain phase,
+// before they are stopped in a later phase, for example to keep producing the logs
+type IDrainer interface {
+	// Drain stops consuming and waits for the in-flight messages until the context is done
+	Drain(ctx context.Context) error
+}
+
+// IHealthChecker optional interface of the services that can report their health
+type IHealthChecker interface {
+	// HealthCheck checks if the service connections are alive
+	HealthCheck(ctx context.Context) error
+}
+
 // IMiddleware the interface of the middlewares
 type IMiddleware interface {
 	RegisterMiddlewares()
@@ -147,11 +202,17 @@ type IHttp interface {
 	ConfigFile() string
 	// Config gets the configurations
 	Config() *httpConfig.Config
+	// ApiKeys gets the accepted api keys
+	ApiKeys() []httpConfig.ApiKey
+	// OpenApi gets the openapi document of the documented endpoints
+	OpenApi() *openapi.Document
 
 	// WithController adds a controller
 	WithMiddleware(controller IMiddleware) IHttp
 	// WithController adds a controller
 	WithController(controller IController) IHttp
+	// WithDocumentation adds the documented routes of the openapi document, for example of a group
+	WithDocumentation(documented openapi.IDocumented) IHttp
 	// WithRouter sets the router
 	WithRouter(router *gin.Engine) IHttp
 	// Router gets the router
@@ -216,6 +277,8 @@ type IRabbitMQ interface {
 
 	// Produce produces to the rabbitmq
 	Produce(message any, exchange string, routingKey string) error
+	// ProduceWithContext produces to the rabbitmq, with the request id of the context
+	ProduceWithContext(ctx context.Context, message any, exchange string, routingKey string) error
 	// Consume consumes from the rabbitmq
 	Consume(app IApp, queues string, handlers map[string]func(msg amqp.Delivery) bool)
 	// WithConsumer adds a consumer to the rabbitmq
@@ -247,6 +310,32 @@ type ISQSConnection interface {
 	Consume(maskedQueue string, consumer ISQSConsumer)
 }
 
+// IHttpClient http client service interface
+type IHttpClient interface {
+	IService
+
+	// WithAdditionalConfigType sets an additional config type
+	WithAdditionalConfigType(obj interface{}) IHttpClient
+	// ConfigFile gets the configuration file
+	ConfigFile() string
+	// Config gets the configurations
+	Config() *httpClientConfig.Config
+	// Upstream gets the client of an upstream
+	Upstream(name string) IHttpUpstream
+}
+
+// IHttpUpstream client of an upstream api
+type IHttpUpstream interface {
+	// Client gets the http client
+	Client() *http.Client
+	// NewRequest creates a request to a path relative to the base url
+	NewRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error)
+	// Do sends a request
+	Do(req *http.Request) (*http.Response, error)
+	// DoJson sends a request with the json of the body, decoding the json of the response
+	DoJson(ctx context.Context, method, path string, body any, response any) error
+}
+
 // IS3 s3 service interface
 type IS3 interface {
 	IService
@@ -292,6 +381,10 @@ type IGrpc interface {
 	GetServer() (conn *grpc.Server, err error)
 	// WithController adds a controller
 	WithController(controller IController) IGrpc
+	// WithUnaryInterceptor adds an unary interceptor to the server
+	WithUnaryInterceptor(interceptor grpc.UnaryServerInterceptor) IGrpc
+	// WithStreamInterceptor adds a stream interceptor to the server
+	WithStreamInterceptor(interceptor grpc.StreamServerInterceptor) IGrpc
 }
 
 // IConsumer defines the rabbitmq consumers interface
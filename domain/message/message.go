@@ -3,17 +3,48 @@ package message
 import (
 	"fmt"
 	"strings"
+	"sync"
 )
 
 const (
-	startedText = "Started"
-	errorText   = "ERROR"
+	startedText  = "Started"
+	errorText    = "ERROR"
+	redactedText = "******"
 )
 
 var (
 	stoppedText = fmt.Sprintf("%sStopped%s", ColorRed, ColorReset)
+	// secrets values that are never written
+	secrets    []string
+	secretsMux sync.RWMutex
 )
 
+// RegisterSecret registers a secret value, so that it is redacted from the messages
+func RegisterSecret(secret string) {
+	if secret == "" {
+		return
+	}
+
+	secretsMux.Lock()
+	defer secretsMux.Unlock()
+	for _, s := range secrets {
+		if s == secret {
+			return
+		}
+	}
+	secrets = append(secrets, secret)
+}
+
+// redact replaces the registered secrets of a text
+func redact(text string) string {
+	secretsMux.RLock()
+	defer secretsMux.RUnlock()
+	for _, secret := range secrets {
+		text = strings.ReplaceAll(text, secret, redactedText)
+	}
+	return text
+}
+
 // StartMessage writes a start message
 func StartMessage(message string) {
 	message = strings.ReplaceAll(message, "\n", fmt.Sprintf("%s :: %s\n:: %s", ColorReset, startedText, ColorGreen))
@@ -31,7 +62,7 @@ func StopMessage(message string) {
 // Message writes a message
 func Message(service string, message string) {
 	text := fmt.Sprintf(":: %s%s%s :: %s", ColorGreen, service, ColorReset, message)
-	fmt.Println(text)
+	fmt.Println(redact(text))
 }
 
 // ErrorMessage writes a error message
@@ -40,5 +71,5 @@ func ErrorMessage(message string, err error) {
 	if err != nil {
 		text = fmt.Sprintf("%s: %s", text, err.Error())
 	}
-	fmt.Println(text)
+	fmt.Println(redact(text))
 }
@@ -62,6 +62,7 @@ type LoggerInfo struct {
 	Level    coreErrors.Level       `json:"level"`
 	Msg      string                 `json:"msg"`
 	SubType  SubType                `json:"log"`
+	TraceId  string                 `json:"traceId"`
 	Response Response               `json:"response"`
 }
 
@@ -71,9 +72,10 @@ type Backend struct {
 }
 
 type Request struct {
-	Method string `json:"method"`
-	Uri    string `json:"uri"`
-	Body   string `json:"body"`
+	Method     string `json:"method"`
+	Uri        string `json:"uri"`
+	Body       string `json:"body"`
+	ApiKeyName string `json:"apiKeyName,omitempty"`
 }
 
 type Response struct {
@@ -100,3 +102,6 @@ type Frontend struct {
 }
 
 type SubType string
+
+// ShutdownPhase phase of the app shutdown in which a service is stopped
+type ShutdownPhase int
@@ -3,7 +3,7 @@ package config
 // Config elastic search configurations
 type Config struct {
 	// Host
-	Host string `yaml:"host"`
+	Host string `yaml:"host" validate:"required"`
 	// Port
 	Port int `yaml:"port"`
 	// User
@@ -1,6 +1,8 @@
 package elastic_search
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/elastic_search/middlewares/tracer"
@@ -36,6 +38,8 @@ type ElasticSearch struct {
 const (
 	// configFile elastic search configuration file
 	configFile = "elastic_search.yaml"
+	// clusterHealthRed cluster health status when some primary shard is not allocated
+	clusterHealthRed = "red"
 )
 
 // New creates a new elastic search
@@ -116,6 +120,32 @@ func (es *ElasticSearch) Client() *elasticsearch.Client {
 	return es.client
 }
 
+// HealthCheck checks the elastic search cluster health
+func (es *ElasticSearch) HealthCheck(ctx context.Context) error {
+	res, err := es.client.Cluster.Health(es.client.Cluster.Health.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("%s", res.String())
+	}
+
+	var health struct {
+		Status string `json:"status"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return err
+	}
+
+	if health.Status == clusterHealthRed {
+		return errorCodes.ErrorElasticClusterHealth().Formats(health.Status)
+	}
+
+	return nil
+}
+
 func (es *ElasticSearch) withMiddleware(tracer estransport.Interface) {
 	es.client.Transport = tracer
 }
@@ -1,6 +1,8 @@
 package elastic_search
 
 import (
+	"context"
+
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	elasticSearchConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/elastic_search/config"
@@ -62,3 +64,9 @@ func (e *ElasticSearchMock) Started() bool {
 	args := e.Called()
 	return args.Get(0).(bool)
 }
+
+// HealthCheck checks the service health
+func (e *ElasticSearchMock) HealthCheck(ctx context.Context) error {
+	args := e.Called(ctx)
+	return args.Error(0)
+}
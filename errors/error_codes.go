@@ -53,4 +53,29 @@ var (
 	ErrorRabbitmqFallback                    = config.GetError("INFRA-44", "Error in RabbitMQ fallback writer: %s", errors.Error)
 	ErrorSQSFallback                         = config.GetError("INFRA-45", "Error in SQS fallback writer: %s", errors.Error)
 	ErrorGettingQueue                        = config.GetError("INFRA-46", "Error getting queue %s: %s", errors.Error)
+	ErrorServiceNotStarted                   = config.GetError("INFRA-47", "Service [%s] is not started", errors.Error)
+	ErrorHealthCheckTimeout                  = config.GetError("INFRA-48", "Service [%s] health check timed out after %s", errors.Error)
+	ErrorRabbitmqConnectionClosed            = config.GetError("INFRA-49", "RabbitMQ %s is closed", errors.Error)
+	ErrorElasticClusterHealth                = config.GetError("INFRA-50", "Elastic Search cluster health is [%s]", errors.Error)
+	ErrorServiceStartTimeout                 = config.GetError("INFRA-51", "Service [%s] did not start within %s", errors.Error)
+	ErrorServiceDependencyCycle              = config.GetError("INFRA-52", "Services dependency cycle detected: %s", errors.Error)
+	ErrorServiceStopTimeout                  = config.GetError("INFRA-53", "Service [%s] did not stop within %s", errors.Error)
+	ErrorInvalidToken                        = config.GetError("INFRA-54", "Unauthorized - invalid token: %s", errors.Error)
+	ErrorTokenExpired                        = config.GetError("INFRA-55", "Unauthorized - token expired", errors.Error)
+	ErrorInvalidJwtKey                       = config.GetError("INFRA-56", "Invalid JWT key: %s", errors.Error)
+	ErrorApiKeyScopeNotAllowed               = config.GetError("INFRA-57", "The API key [%s] is not allowed for the scope [%s]", errors.Error)
+	ErrorInvalidSignatureCookie              = config.GetError("INFRA-58", "Invalid signature cookie", errors.Error)
+	ErrorSessionExpired                      = config.GetError("INFRA-59", "Unauthorized - session expired", errors.Error)
+	ErrorInvalidSessionConfig                = config.GetError("INFRA-60", "Invalid session configurations: %s", errors.Error)
+	ErrorCorsOriginNotAllowed                = config.GetError("INFRA-61", "The origin [%s] is not allowed", errors.Error)
+	ErrorInvalidCorsConfig                   = config.GetError("INFRA-62", "Invalid CORS configurations: %s", errors.Error)
+	ErrorRateLimitExceeded                   = config.GetError("INFRA-63", "Too many requests, retry after %s", errors.Error)
+	ErrorInvalidRequest                      = config.GetError("INFRA-64", "Invalid request: %s", errors.Info)
+	ErrorHttpClient                          = config.GetError("INFRA-65", "Something went wrong, please contact an administrator [HTTP]", errors.Error)
+	ErrorHttpClientCircuitOpen               = config.GetError("INFRA-66", "The circuit of the upstream [%s] is open", errors.Error)
+	ErrorHttpClientTimeout                   = config.GetError("INFRA-67", "The request to the upstream [%s] timed out", errors.Error)
+	ErrorHttpClientStatus                    = config.GetError("INFRA-68", "The upstream [%s] responded with the status [%d]", errors.Error)
+	ErrorPanicRecovered                      = config.GetError("INFRA-69", "Something went wrong, please contact an administrator", errors.Error)
+	ErrorRequestBodyTooLarge                 = config.GetError("INFRA-70", "The request body exceeds the limit of [%d] bytes", errors.Error)
+	ErrorInvalidTlsConfig                    = config.GetError("INFRA-71", "Invalid TLS configurations: %s", errors.Error)
 )
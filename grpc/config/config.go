@@ -5,7 +5,7 @@ type Configs struct {
 	// Server
 	Server Config `json:"server"`
 	// Clients
-	Clients []Config `json:"clients"`
+	Clients []Config `json:"clients" validate:"dive"`
 	// Additional Config
 	AdditionalConfig interface{} `yaml:"additionalConfig"`
 }
@@ -15,7 +15,7 @@ type Config struct {
 	// Name
 	Name string `yaml:"name"`
 	// Host
-	Host string `yaml:"host"`
+	Host string `yaml:"host" validate:"required_with=Name"`
 	// Port
-	Port int `yaml:"port"`
+	Port int `yaml:"port" validate:"required_with=Name,max=65535"`
 }
@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -40,6 +41,10 @@ type Grpc struct {
 	clients map[string]*grpc.ClientConn
 	// Controllers
 	controllers []domain.IController
+	// Unary Interceptors of the server
+	unaryInterceptors []grpc.UnaryServerInterceptor
+	// Stream Interceptors of the server
+	streamInterceptors []grpc.StreamServerInterceptor
 	// Initialized Server
 	initializedServer bool
 	// Initialized Clients
@@ -48,6 +53,8 @@ type Grpc struct {
 	writer io.Writer
 	// Additional Config Type
 	additionalConfigType interface{}
+	// Failed Channel
+	failed chan error
 	// Started
 	started bool
 }
@@ -65,6 +72,7 @@ func New(app domain.IApp, configs *grpcConfig.Configs) *Grpc {
 		app:     app,
 		clients: map[string]*grpc.ClientConn{},
 		writer:  serviceWriter.NewServiceWriter(serviceName),
+		failed:  make(chan error, 1),
 	}
 
 	if configs != nil {
@@ -89,6 +97,17 @@ func (g *Grpc) Name() string {
 	return strings.Join(text, "\n")
 }
 
+// DependsOn the server only accepts traffic after every other service, except http, is started
+func (g *Grpc) DependsOn() (services []domain.IService) {
+	for _, s := range g.app.Services() {
+		if s == domain.IService(g) || (g.app.Http() != nil && s == domain.IService(g.app.Http())) {
+			continue
+		}
+		services = append(services, s)
+	}
+	return services
+}
+
 // Start starts a grpc service
 func (g *Grpc) Start() (err error) {
 	// initialize configs
@@ -117,8 +136,34 @@ func (g *Grpc) Start() (err error) {
 
 // Stop stops a grpc service
 func (g *Grpc) Stop() (err error) {
+	return g.Shutdown(context.Background())
+}
+
+// Failed gets the channel where the server errors are sent after the start
+func (g *Grpc) Failed() <-chan error {
+	return g.failed
+}
+
+// ShutdownPhase the grpc server stops accepting traffic first
+func (g *Grpc) ShutdownPhase() domain.ShutdownPhase {
+	return domain.ShutdownPhaseTraffic
+}
+
+// Shutdown stops accepting new rpcs and waits for the pending ones until the context is done
+func (g *Grpc) Shutdown(ctx context.Context) (err error) {
 	if g.initializedServer {
-		g.server.GracefulStop()
+		stopped := make(chan struct{})
+		go func() {
+			g.server.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			// the deadline was exceeded, so the pending rpcs are cancelled
+			g.server.Stop()
+		}
 	}
 
 	if g.initializedClients {
@@ -182,14 +227,16 @@ func (g *Grpc) InitServer() (err error) {
 	// create server with interceptor
 	g.server = grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
-		grpc.ChainUnaryInterceptor(
+		grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{
+			interceptor.RequestIdServerInterceptor(),
 			interceptor.ErrorServerInterceptor(),
 			interceptor.PrintServerInterceptor(g.writer),
-		),
-		grpc.ChainStreamInterceptor(
+		}, g.unaryInterceptors...)...),
+		grpc.ChainStreamInterceptor(append([]grpc.StreamServerInterceptor{
+			interceptor.RequestIdServerStreamingInterceptor(),
 			interceptor.ErrorServerStreamingInterceptor(),
 			interceptor.PrintServerStreamingInterceptor(g.writer),
-		),
+		}, g.streamInterceptors...)...),
 	)
 
 	// register implementations of the server
@@ -204,10 +251,14 @@ func (g *Grpc) InitServer() (err error) {
 
 	// create go routine to listen grpc
 	status := make(chan error, 1)
-	defer close(status)
 	go func(status chan error) {
-		if err = g.server.Serve(lis); err != nil {
-			status <- err
+		if errServe := g.server.Serve(lis); errServe != nil {
+			// the error is reported to the start or, if it already returned, to the app
+			status <- errServe
+			select {
+			case g.failed <- errServe:
+			default:
+			}
 		}
 	}(status)
 
@@ -252,10 +303,12 @@ func (g *Grpc) InitClients() (err error) {
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
 			grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 			grpc.WithChainUnaryInterceptor(
+				interceptor.RequestIdClientInterceptor(),
 				interceptor.ErrorClientInterceptor(),
 				interceptor.PrintClientInterceptor(g.writer),
 			),
 			grpc.WithChainStreamInterceptor(
+				interceptor.RequestIdClientStreamingInterceptor(),
 				interceptor.ErrorClientStreamingInterceptor(),
 				interceptor.PrintClientStreamingInterceptor(g.writer),
 			),
@@ -318,6 +371,18 @@ func (g *Grpc) WithController(controller domain.IController) domain.IGrpc {
 	return g
 }
 
+// WithUnaryInterceptor adds an unary interceptor to the server, after the request id, error and print interceptors
+func (g *Grpc) WithUnaryInterceptor(interceptor grpc.UnaryServerInterceptor) domain.IGrpc {
+	g.unaryInterceptors = append(g.unaryInterceptors, interceptor)
+	return g
+}
+
+// WithStreamInterceptor adds a stream interceptor to the server, after the request id, error and print interceptors
+func (g *Grpc) WithStreamInterceptor(interceptor grpc.StreamServerInterceptor) domain.IGrpc {
+	g.streamInterceptors = append(g.streamInterceptors, interceptor)
+	return g
+}
+
 // WithAdditionalConfigType sets an additional config type
 func (g *Grpc) WithAdditionalConfigType(obj interface{}) domain.IGrpc {
 	g.additionalConfigType = obj
@@ -67,6 +67,22 @@ func (g *GrpcMock) WithController(controller domain.IController) domain.IGrpc {
 	return args.Get(0).(domain.IGrpc)
 }
 
+func (g *GrpcMock) WithUnaryInterceptor(interceptor grpc.UnaryServerInterceptor) domain.IGrpc {
+	args := g.Called(interceptor)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(domain.IGrpc)
+}
+
+func (g *GrpcMock) WithStreamInterceptor(interceptor grpc.StreamServerInterceptor) domain.IGrpc {
+	args := g.Called(interceptor)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(domain.IGrpc)
+}
+
 // WithAdditionalConfigType sets an additional config type
 func (g *GrpcMock) WithAdditionalConfigType(obj interface{}) domain.IApp {
 	args := g.Called(obj)
@@ -0,0 +1,125 @@
+package interceptor
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/ratelimit"
+)
+
+const (
+	rateLimitLimitTag     = "ratelimit-limit"
+	rateLimitRemainingTag = "ratelimit-remaining"
+	rateLimitResetTag     = "ratelimit-reset"
+	retryAfterTag         = "retry-after"
+)
+
+// RateLimitKeyFunc gets a part of the key of a call
+type RateLimitKeyFunc func(ctx context.Context, fullMethod string) string
+
+// ByPeer gets the ip of the client
+func ByPeer(ctx context.Context, _ string) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "ip:"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return "ip:" + host
+}
+
+// ByMethod gets the method, that limits the calls of every client of the method together
+func ByMethod(_ context.Context, fullMethod string) string {
+	return "method:" + fullMethod
+}
+
+// ByMetadata gets a value of the metadata, for example an api key, or the ip of the client without it
+func ByMetadata(name string) RateLimitKeyFunc {
+	return func(ctx context.Context, fullMethod string) string {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(name); len(values) > 0 && values[0] != "" {
+			return name + ":" + values[0]
+		}
+		return ByPeer(ctx, fullMethod)
+	}
+}
+
+// RateLimitServerInterceptor limits the calls of the keys, by the ip of the client by default
+func RateLimitServerInterceptor(limiter *ratelimit.Limiter, rule ratelimit.Rule, keys ...RateLimitKeyFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := allow(ctx, limiter, rule, info.FullMethod, keys, func(md metadata.MD) error {
+			return grpc.SetHeader(ctx, md)
+		}); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitServerStreamingInterceptor limits the streams of the keys, by the ip of the client by default
+func RateLimitServerStreamingInterceptor(limiter *ratelimit.Limiter, rule ratelimit.Rule, keys ...RateLimitKeyFunc) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := allow(ss.Context(), limiter, rule, info.FullMethod, keys, ss.SetHeader); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// allow checks if a call is allowed, setting the rate limit headers
+func allow(ctx context.Context, limiter *ratelimit.Limiter, rule ratelimit.Rule, fullMethod string,
+	keys []RateLimitKeyFunc, setHeader func(md metadata.MD) error) error {
+	if len(keys) == 0 {
+		keys = []RateLimitKeyFunc{ByPeer}
+	}
+
+	key := ""
+	for i, keyFunc := range keys {
+		if i > 0 {
+			key += ":"
+		}
+		key += keyFunc(ctx, fullMethod)
+	}
+
+	result, err := limiter.Allow(ctx, key, rule)
+	if err != nil {
+		// the calls are not blocked when the limits are unavailable
+		return nil
+	}
+
+	md := metadata.Pairs(
+		rateLimitLimitTag, strconv.Itoa(result.Limit),
+		rateLimitRemainingTag, strconv.Itoa(result.Remaining),
+		rateLimitResetTag, seconds(result.Reset),
+	)
+
+	if result.Allowed {
+		_ = setHeader(md)
+		return nil
+	}
+
+	md.Set(retryAfterTag, seconds(result.RetryAfter))
+	_ = setHeader(md)
+
+	return errorCodes.ErrorRateLimitExceeded().Formats(seconds(result.RetryAfter) + "s").
+		SetStatusCode(http.StatusTooManyRequests)
+}
+
+// seconds gets the seconds of a duration, rounded up
+func seconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
@@ -0,0 +1,87 @@
+package interceptor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/tracer"
+)
+
+// requestIdServerStream server stream with the context of the request id
+type requestIdServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context gets the context with the request id
+func (s *requestIdServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// RequestIdServerInterceptor reads the request id of the metadata, or creates a new one, and returns it in the headers
+func RequestIdServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withRequestId(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(contextInfra.MetadataRequestId, contextInfra.RequestId(ctx)))
+
+		return handler(ctx, req)
+	}
+}
+
+// RequestIdServerStreamingInterceptor reads the request id of the metadata, or creates a new one, and returns it in the headers
+func RequestIdServerStreamingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestId(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(contextInfra.MetadataRequestId, contextInfra.RequestId(ctx)))
+
+		return handler(srv, &requestIdServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// RequestIdClientInterceptor sends the request id of the context in the metadata
+func RequestIdClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingRequestId(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// RequestIdClientStreamingInterceptor sends the request id of the context in the metadata
+func RequestIdClientStreamingInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestId(ctx), desc, cc, method, opts...)
+	}
+}
+
+// withRequestId sets the request id of the incoming metadata, or a new one, in the context and in the span
+func withRequestId(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	id := ""
+	if ids := md.Get(contextInfra.MetadataRequestId); len(ids) > 0 && contextInfra.ValidRequestId(ids[0]) {
+		id = ids[0]
+	} else {
+		id = contextInfra.NewRequestId()
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String(tracer.TracerTagRequestId, id))
+	return contextInfra.WithRequestId(ctx, id)
+}
+
+// outgoingRequestId adds the request id of the context to the outgoing metadata, when it is not there
+func outgoingRequestId(ctx context.Context) context.Context {
+	id := contextInfra.RequestId(ctx)
+	if id == "" {
+		return ctx
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(contextInfra.MetadataRequestId)) > 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, contextInfra.MetadataRequestId, id)
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
+)
+
+// newCapturePolicy creates the capture policy of the bodies, consumed by the logs and the traces
+func (h *Http) newCapturePolicy() *capture.Policy {
+	var excluders []capture.Excluder
+
+	if h.app.Logger() != nil && h.app.Logger().Log() != nil {
+		if excluder, ok := h.app.Logger().Log().(capture.Excluder); ok {
+			excluders = append(excluders, excluder)
+		}
+	}
+
+	if h.app.Tracer() != nil {
+		if excluder, ok := h.app.Tracer().(capture.Excluder); ok {
+			excluders = append(excluders, excluder)
+		}
+	}
+
+	return capture.NewPolicy(h.config.Capture, excluders...)
+}
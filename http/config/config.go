@@ -1,19 +1,272 @@
 package config
 
+import captureConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture/config"
+
+// AllScopes scope that allows every scope to an api key
+const AllScopes = "*"
+
 // Config http configurations
 type Config struct {
 	// Host
 	Host string `yaml:"host"`
 	// Port
-	Port int `yaml:"port"`
-	// JWT Secret
+	Port int `yaml:"port" validate:"min=1,max=65535"`
+	// Server timeouts and protocols
+	Server ServerConfig `yaml:"server"`
+	// Tls
+	Tls TlsConfig `yaml:"tls"`
+	// Body Limit of the requests
+	BodyLimit BodyLimitConfig `yaml:"bodyLimit"`
+	// Capture of the bodies for the logs and the traces
+	Capture captureConfig.Config `yaml:"capture"`
+	// JWT Secret of the HS algorithms
 	JwtSecret string `yaml:"jwtSecret"`
 	// Jwt Expiry Time Hours
-	JwtExpiryTimeHours int `yaml:"jwtExpiryTimeHours"`
-	// Cookie Inactivity Minutes
-	CookieInactivityMinutes int `yaml:"cookieInactivityMinutes"`
-	// Api Keys
+	JwtExpiryTimeHours int `yaml:"jwtExpiryTimeHours" validate:"min=0"`
+	// Jwt Algorithm (HS256, HS384, HS512, RS256, RS384 or RS512)
+	JwtAlgorithm string `yaml:"jwtAlgorithm" default:"HS256" validate:"oneof=HS256 HS384 HS512 RS256 RS384 RS512"`
+	// Jwt Public Key (PEM) of the RS algorithms, to validate the tokens
+	JwtPublicKey string `yaml:"jwtPublicKey"`
+	// Jwt Private Key (PEM) of the RS algorithms, to sign the tokens
+	JwtPrivateKey string `yaml:"jwtPrivateKey"`
+	// Jwt Issuer of the signed tokens, the tokens of other issuers are rejected when set
+	JwtIssuer string `yaml:"jwtIssuer"`
+	// Jwt Audience of the signed tokens, the tokens without the audience are rejected when set
+	JwtAudience string `yaml:"jwtAudience"`
+	// Jwt Max Refresh Hours since the first issue of a token, after which it is not refreshed, without limit when negative
+	JwtMaxRefreshHours int `yaml:"jwtMaxRefreshHours" default:"168" validate:"min=-1"`
+	// Cookie Inactivity Minutes, after which the sessions expire
+	CookieInactivityMinutes int `yaml:"cookieInactivityMinutes" default:"30" validate:"min=0"`
+	// Session cookie sessions
+	Session SessionConfig `yaml:"session"`
+	// Api Keys without name and scopes
 	ApiKeys []string `yaml:"apiKeys"`
+	// Api Key authentication
+	ApiKey ApiKeyConfig `yaml:"apiKey"`
+	// Cors
+	Cors CorsConfig `yaml:"cors"`
+	// Rate Limit
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+	// Compression of the responses
+	Compression CompressionConfig `yaml:"compression"`
+	// Cache of the responses
+	Cache CacheConfig `yaml:"cache"`
+	// Health
+	Health HealthConfig `yaml:"health"`
+	// Open Api document
+	OpenApi OpenApiConfig `yaml:"openApi"`
+	// Metrics of the requests
+	Metrics MetricsConfig `yaml:"metrics"`
+	// Recovery of the panics
+	Recovery RecoveryConfig `yaml:"recovery"`
 	// Additional Config
 	AdditionalConfig interface{} `yaml:"additionalConfig"`
 }
+
+// ServerConfig server timeouts and protocols configurations
+type ServerConfig struct {
+	// Read Header Timeout Seconds
+	ReadHeaderTimeoutSeconds int `yaml:"readHeaderTimeoutSeconds" default:"10" validate:"min=0"`
+	// Read Timeout Seconds of the whole request, including the body
+	ReadTimeoutSeconds int `yaml:"readTimeoutSeconds" default:"60" validate:"min=0"`
+	// Write Timeout Seconds of the response, disabled when zero so that the streams are not cut
+	WriteTimeoutSeconds int `yaml:"writeTimeoutSeconds" validate:"min=0"`
+	// Idle Timeout Seconds of the keep-alive connections
+	IdleTimeoutSeconds int `yaml:"idleTimeoutSeconds" default:"120" validate:"min=0"`
+	// Max Header Bytes
+	MaxHeaderBytes int `yaml:"maxHeaderBytes" default:"1048576" validate:"min=0"`
+	// H2c serves HTTP/2 without TLS
+	H2c bool `yaml:"h2c"`
+	// Shutdown Grace Seconds to wait for the active requests, limited by the shutdown of the app
+	ShutdownGraceSeconds int `yaml:"shutdownGraceSeconds" validate:"min=0"`
+}
+
+// TlsConfig tls configurations, with a certificate or with a directory of certificates
+type TlsConfig struct {
+	// Enabled
+	Enabled bool `yaml:"enabled"`
+	// Cert File (PEM)
+	CertFile string `yaml:"certFile"`
+	// Key File (PEM)
+	KeyFile string `yaml:"keyFile"`
+	// Cert Dir with pairs of <name>.crt and <name>.key files, selected by the server name
+	CertDir string `yaml:"certDir"`
+	// Reload Seconds interval to check if the files changed, disabled when negative
+	ReloadSeconds int `yaml:"reloadSeconds" default:"60" validate:"min=-1"`
+	// Min Version (1.2 or 1.3)
+	MinVersion string `yaml:"minVersion" default:"1.2" validate:"oneof=1.2 1.3"`
+}
+
+// BodyLimitConfig body limit of the requests configurations
+type BodyLimitConfig struct {
+	// Max Bytes of the bodies, without limit when negative
+	MaxBytes int64 `yaml:"maxBytes" default:"10485760" validate:"min=-1"`
+	// Routes limits of the routes, that replace the max bytes
+	Routes []RouteBodyLimit `yaml:"routes" validate:"dive"`
+}
+
+// RouteBodyLimit body limit of a route
+type RouteBodyLimit struct {
+	// Method, every method when empty
+	Method string `yaml:"method"`
+	// Path route template (/files/:id)
+	Path string `yaml:"path" validate:"required"`
+	// Max Bytes of the bodies, without limit when negative
+	MaxBytes int64 `yaml:"maxBytes" validate:"min=-1"`
+}
+
+// ApiKeyConfig api key authentication configurations
+type ApiKeyConfig struct {
+	// Header with the key
+	Header string `yaml:"header" default:"X-Api-Key"`
+	// Query Param with the key, disabled when empty
+	QueryParam string `yaml:"queryParam"`
+	// Keys
+	Keys []ApiKey `yaml:"keys" validate:"dive"`
+}
+
+// ApiKey api key of an integration
+type ApiKey struct {
+	// Name of the integration
+	Name string `yaml:"name" validate:"required"`
+	// Key
+	Key string `yaml:"key" validate:"required"`
+	// Scopes allowed to the key, every scope is allowed with "*"
+	Scopes []string `yaml:"scopes"`
+}
+
+// SessionConfig cookie sessions configurations
+type SessionConfig struct {
+	// Cookie Name, the signature is in the cookie with the ".sig" suffix
+	CookieName string `yaml:"cookieName" default:"session"`
+	// Secret to sign the cookies
+	Secret string `yaml:"secret"`
+	// Encryption Key to encrypt the cookies, disabled when empty
+	EncryptionKey string `yaml:"encryptionKey"`
+	// Store of the sessions (cookie or redis)
+	Store string `yaml:"store" default:"cookie" validate:"oneof=cookie redis"`
+	// Redis Prefix of the keys of the redis store
+	RedisPrefix string `yaml:"redisPrefix" default:"session:"`
+	// Path of the cookies
+	Path string `yaml:"path" default:"/"`
+	// Domain of the cookies
+	Domain string `yaml:"domain"`
+	// Secure sends the cookies only over https
+	Secure bool `yaml:"secure"`
+	// Same Site (lax, strict or none)
+	SameSite string `yaml:"sameSite" default:"lax" validate:"oneof=lax strict none"`
+}
+
+// CorsConfig cors configurations
+type CorsConfig struct {
+	// Enabled registers the cors middleware in every route
+	Enabled bool `yaml:"enabled"`
+	// Policy of every route
+	Policy CorsPolicy `yaml:"policy"`
+	// Groups policies of the groups, that replace the policy of every route
+	Groups []CorsGroupPolicy `yaml:"groups" validate:"dive"`
+}
+
+// CorsGroupPolicy cors policy of a group
+type CorsGroupPolicy struct {
+	// Path of the group
+	Path string `yaml:"path" validate:"required"`
+	// Policy
+	Policy CorsPolicy `yaml:"policy"`
+}
+
+// CorsPolicy cors policy
+type CorsPolicy struct {
+	// Allow Origins, with "*" for every origin, wildcards (https://*.example.com) or regular expressions (regex:^https://.*$)
+	AllowOrigins []string `yaml:"allowOrigins"`
+	// Allow Methods
+	AllowMethods []string `yaml:"allowMethods" default:"GET,POST,PUT,PATCH,DELETE,HEAD,OPTIONS"`
+	// Allow Headers, the requested headers are allowed when empty
+	AllowHeaders []string `yaml:"allowHeaders"`
+	// Expose Headers
+	ExposeHeaders []string `yaml:"exposeHeaders"`
+	// Allow Credentials
+	AllowCredentials bool `yaml:"allowCredentials"`
+	// Max Age Seconds of the preflight responses in the browser cache
+	MaxAgeSeconds int `yaml:"maxAgeSeconds" validate:"min=0"`
+}
+
+// RateLimitConfig rate limit configurations
+type RateLimitConfig struct {
+	// Store of the limits (redis or memory), the memory is used when the redis fails
+	Store string `yaml:"store" default:"redis" validate:"oneof=redis memory"`
+	// Prefix of the redis keys
+	Prefix string `yaml:"prefix" default:"ratelimit:"`
+}
+
+// CacheConfig cache of the responses configurations
+type CacheConfig struct {
+	// Prefix of the redis keys
+	Prefix string `yaml:"prefix" default:"cache:"`
+	// Max Body Bytes of the responses that are cached
+	MaxBodyBytes int `yaml:"maxBodyBytes" default:"1048576" validate:"min=0"`
+}
+
+// CompressionConfig compression of the responses configurations
+type CompressionConfig struct {
+	// Enabled registers the compression middleware in every route
+	Enabled bool `yaml:"enabled"`
+	// Encodings supported, by the order of preference, other than gzip and deflate added with WithCompressionEncoder
+	Encodings []string `yaml:"encodings" default:"gzip,deflate" validate:"dive,required"`
+	// Level of the compression, between 1 (speed) and 9 (size), the default of the encoders when zero
+	Level int `yaml:"level" validate:"min=0,max=9"`
+	// Min Bytes of the responses that are compressed
+	MinBytes int `yaml:"minBytes" default:"1024" validate:"min=0"`
+	// Content Types compressed, with wildcard subtypes (text/*)
+	ContentTypes []string `yaml:"contentTypes" default:"application/json,application/problem+json,application/xml,application/javascript,image/svg+xml,text/*"`
+}
+
+// HealthConfig health check endpoints configurations
+type HealthConfig struct {
+	// Disabled
+	Disabled bool `yaml:"disabled"`
+	// Live Path
+	LivePath string `yaml:"livePath"`
+	// Ready Path
+	ReadyPath string `yaml:"readyPath"`
+	// Timeout Seconds of each check
+	TimeoutSeconds int `yaml:"timeoutSeconds" validate:"min=0"`
+}
+
+// MetricsConfig metrics of the requests configurations
+type MetricsConfig struct {
+	// Disabled
+	Disabled bool `yaml:"disabled"`
+	// Duration Buckets of the latency histogram, in seconds
+	DurationBuckets []float64 `yaml:"durationBuckets" default:"0.005,0.01,0.025,0.05,0.1,0.25,0.5,1,2.5,5,10"`
+	// Size Buckets of the request and response size histograms, in bytes
+	SizeBuckets []float64 `yaml:"sizeBuckets" default:"100,1000,10000,100000,1000000,10000000"`
+	// Exclude Routes route templates (/users/:id) without metrics
+	ExcludeRoutes []string `yaml:"excludeRoutes" default:"/metrics,/health/live,/health/ready"`
+}
+
+// RecoveryConfig recovery of the panics configurations
+type RecoveryConfig struct {
+	// Disable Stack of the panics in the logs
+	DisableStack bool `yaml:"disableStack"`
+	// Include Body of the requests in the logs of the panics
+	IncludeBody bool `yaml:"includeBody"`
+}
+
+// OpenApiConfig openapi document configurations
+type OpenApiConfig struct {
+	// Enabled serves the document of the documented endpoints
+	Enabled bool `yaml:"enabled"`
+	// Path of the document
+	Path string `yaml:"path" default:"/openapi.json"`
+	// Swagger Ui serves the swagger ui of the document
+	SwaggerUi bool `yaml:"swaggerUi"`
+	// Swagger Ui Path
+	SwaggerUiPath string `yaml:"swaggerUiPath" default:"/docs"`
+	// Title of the api, the name of the app when empty
+	Title string `yaml:"title"`
+	// Version of the api
+	Version string `yaml:"version" default:"1.0.0"`
+	// Description of the api
+	Description string `yaml:"description"`
+}
@@ -4,6 +4,7 @@ import (
 	"net/url"
 
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/openapi"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,6 +19,8 @@ type Endpoint struct {
 	method string
 	// Middleware
 	middlewares []gin.HandlerFunc
+	// Doc openapi documentation
+	doc *openapi.EndpointDoc
 }
 
 // NewEndpoint creates a new endpoint
@@ -49,6 +52,60 @@ func (e *Endpoint) AddMiddlewares(middlewares domain.IMiddleware) {
 func (e *Endpoint) SetRoute(engine *gin.Engine, handlerFunc ...gin.HandlerFunc) {
 	e.middlewares = append(e.middlewares, handlerFunc...)
 	e.group.Init(&engine.RouterGroup).Handle(e.Method(), e.Path(), e.middlewares...)
+	e.group.addRoute(openapi.Route{Method: e.Method(), Path: e.FullPath(), Doc: e.doc})
+}
+
+// WithSummary sets the summary of the documentation
+func (e *Endpoint) WithSummary(summary string) *Endpoint {
+	e.Doc().Summary = summary
+	return e
+}
+
+// WithDescription sets the description of the documentation
+func (e *Endpoint) WithDescription(description string) *Endpoint {
+	e.Doc().Description = description
+	return e
+}
+
+// WithTags sets the tags of the documentation
+func (e *Endpoint) WithTags(tags ...string) *Endpoint {
+	e.Doc().Tags = append(e.Doc().Tags, tags...)
+	return e
+}
+
+// WithParams sets the struct with the path (uri tag), query (form tag) and header (header tag) parameters
+func (e *Endpoint) WithParams(params any) *Endpoint {
+	e.Doc().Params = params
+	return e
+}
+
+// WithRequest sets the type of the request body
+func (e *Endpoint) WithRequest(request any) *Endpoint {
+	e.Doc().Request = request
+	return e
+}
+
+// WithResponse sets the type of the response data of a status code
+func (e *Endpoint) WithResponse(status int, data any) *Endpoint {
+	if e.Doc().Responses == nil {
+		e.Doc().Responses = make(map[int]any)
+	}
+	e.Doc().Responses[status] = data
+	return e
+}
+
+// Deprecated marks the endpoint as deprecated in the documentation
+func (e *Endpoint) Deprecated() *Endpoint {
+	e.Doc().Deprecated = true
+	return e
+}
+
+// Doc gets the openapi documentation
+func (e *Endpoint) Doc() *openapi.EndpointDoc {
+	if e.doc == nil {
+		e.doc = &openapi.EndpointDoc{}
+	}
+	return e.doc
 }
 
 // FullPath full endpoint path
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/openapi"
 )
 
 // Group group struct
@@ -18,6 +19,7 @@ type Group struct {
 	middlewares []domain.IMiddleware
 	endpoints   []Endpoint
 	initalized  bool
+	routes      []openapi.Route
 }
 
 // NewGroup creates a new group
@@ -87,3 +89,23 @@ func (g *Group) GetHandlers() (handlers []gin.HandlerFunc) {
 	}
 	return
 }
+
+// DocumentedRoutes gets the routes set in the group and in its sub groups, that are added to the openapi document
+// with WithDocumentation of the http service
+func (g *Group) DocumentedRoutes() []openapi.Route {
+	return g.root().routes
+}
+
+// addRoute adds a route to the root group, which documents the routes of every sub group
+func (g *Group) addRoute(route openapi.Route) {
+	root := g.root()
+	root.routes = append(root.routes, route)
+}
+
+// root gets the root group
+func (g *Group) root() *Group {
+	for g.group != nil {
+		g = g.group
+	}
+	return g
+}
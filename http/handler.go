@@ -0,0 +1,151 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	contextDomain "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/context"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+)
+
+// HandlerFunc typed handler, that receives the bound and validated request and returns the response data
+type HandlerFunc[Req any, Resp any] func(ctx contextDomain.IContext, req Req) (Resp, error)
+
+// bindings sources of a request, by the tags of its fields
+type bindings struct {
+	uri    bool
+	query  bool
+	header bool
+	body   bool
+}
+
+// Handle adapts a typed handler to a gin handler, that binds the request from the path (uri tag),
+// query (form tag), headers (header tag) and json body, validates it and writes the response
+// with the pagination and meta of the context. The endpoint, when set, is documented with the types
+func Handle[Req any, Resp any](app domain.IApp, endpoint *Endpoint, handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
+	var empty Req
+	sources := requestBindings(reflect.TypeOf(empty))
+	controller := domain.NewDefaultController(app)
+
+	if endpoint != nil {
+		documentHandler[Req, Resp](endpoint, sources)
+	}
+
+	return func(gCtx *gin.Context) {
+		ctx := context.NewContext(gCtx)
+
+		var req Req
+		if err := bind(gCtx, &req, sources); err != nil {
+			controller.Json(ctx, nil, errorCodes.ErrorInvalidRequest().Formats(err))
+			return
+		}
+
+		if app.Validator() != nil {
+			if err := app.Validator().Validate(ctx, &req); err != nil {
+				controller.Json(ctx, nil, err)
+				return
+			}
+		}
+
+		resp, err := handler(ctx, req)
+		controller.Json(ctx, resp, err)
+	}
+}
+
+// bind binds the request from its sources
+// The body is decoded first, so that the uri, query and header values are not replaced by the fields of the body
+func bind(gCtx *gin.Context, req any, sources bindings) error {
+	if sources.body && gCtx.Request.Body != nil && gCtx.Request.ContentLength != 0 {
+		// an empty body keeps the zero values
+		if err := json.NewDecoder(gCtx.Request.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+
+	if sources.uri && len(gCtx.Params) > 0 {
+		if err := gCtx.ShouldBindUri(req); err != nil {
+			return err
+		}
+	}
+
+	if sources.query {
+		if err := gCtx.ShouldBindQuery(req); err != nil {
+			return err
+		}
+	}
+
+	if sources.header {
+		if err := gCtx.ShouldBindHeader(req); err != nil {
+			return err
+		}
+	}
+
+	// the binding tags of the body are validated once every source is bound
+	if sources.body && binding.Validator != nil {
+		return binding.Validator.ValidateStruct(req)
+	}
+
+	return nil
+}
+
+// requestBindings gets the sources of a request, the fields without uri, form or header tags are bound from the body
+func requestBindings(t reflect.Type) (sources bindings) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		sources.body = t != nil
+		return sources
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			embedded := requestBindings(field.Type)
+			sources.uri = sources.uri || embedded.uri
+			sources.query = sources.query || embedded.query
+			sources.header = sources.header || embedded.header
+			sources.body = sources.body || embedded.body
+			continue
+		}
+
+		_, uri := field.Tag.Lookup("uri")
+		_, query := field.Tag.Lookup("form")
+		_, header := field.Tag.Lookup("header")
+		sources.uri = sources.uri || uri
+		sources.query = sources.query || query
+		sources.header = sources.header || header
+		sources.body = sources.body || (field.IsExported() && !uri && !query && !header)
+	}
+
+	return sources
+}
+
+// documentHandler documents an endpoint with the types of a handler, keeping the existing documentation
+func documentHandler[Req any, Resp any](endpoint *Endpoint, sources bindings) {
+	var req Req
+	var resp Resp
+	doc := endpoint.Doc()
+
+	if doc.Params == nil && (sources.uri || sources.query || sources.header) {
+		doc.Params = req
+	}
+
+	if doc.Request == nil && sources.body {
+		switch endpoint.Method() {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			doc.Request = req
+		}
+	}
+
+	if len(doc.Responses) == 0 {
+		endpoint.WithResponse(http.StatusOK, resp)
+	}
+}
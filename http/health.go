@@ -0,0 +1,160 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+)
+
+const (
+	// defaultHealthLivePath default liveness path
+	defaultHealthLivePath = "/health/live"
+	// defaultHealthReadyPath default readiness path
+	defaultHealthReadyPath = "/health/ready"
+	// defaultHealthTimeout default timeout of each check
+	defaultHealthTimeout = 2 * time.Second
+)
+
+// Health status
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// HealthResponse aggregated health response
+type HealthResponse struct {
+	// Status
+	Status string `json:"status"`
+	// Checks
+	Checks []HealthCheckResult `json:"checks,omitempty"`
+}
+
+// HealthCheckResult health of a service
+type HealthCheckResult struct {
+	// Name
+	Name string `json:"name"`
+	// Status
+	Status string `json:"status"`
+	// Duration Milliseconds
+	DurationMs int64 `json:"durationMs"`
+	// Error
+	Error string `json:"error,omitempty"`
+}
+
+// registerHealthChecks registers the liveness and readiness endpoints
+func (h *Http) registerHealthChecks() {
+	if h.config.Health.Disabled {
+		return
+	}
+
+	livePath := h.config.Health.LivePath
+	if livePath == "" {
+		livePath = defaultHealthLivePath
+	}
+
+	readyPath := h.config.Health.ReadyPath
+	if readyPath == "" {
+		readyPath = defaultHealthReadyPath
+	}
+
+	h.router.GET(livePath, h.live)
+	h.router.GET(readyPath, h.ready)
+}
+
+// live answers while the process is able to handle requests
+func (h *Http) live(gCtx *gin.Context) {
+	gCtx.JSON(http.StatusOK, HealthResponse{Status: HealthStatusUp})
+}
+
+// ready answers if every service is started and healthy
+func (h *Http) ready(gCtx *gin.Context) {
+	response := h.CheckHealth(gCtx.Request.Context())
+
+	statusCode := http.StatusOK
+	if response.Status != HealthStatusUp {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	gCtx.JSON(statusCode, response)
+}
+
+// CheckHealth checks the health of every app service
+func (h *Http) CheckHealth(ctx context.Context) HealthResponse {
+	services := h.app.Services()
+	response := HealthResponse{
+		Status: HealthStatusUp,
+		Checks: make([]HealthCheckResult, len(services)),
+	}
+
+	wg := sync.WaitGroup{}
+	for i, service := range services {
+		wg.Add(1)
+		go func(i int, service domain.IService) {
+			defer wg.Done()
+			response.Checks[i] = h.checkService(ctx, service)
+		}(i, service)
+	}
+	wg.Wait()
+
+	for _, check := range response.Checks {
+		if check.Status != HealthStatusUp {
+			response.Status = HealthStatusDown
+			break
+		}
+	}
+
+	return response
+}
+
+// checkService checks the health of a service within the configured timeout
+func (h *Http) checkService(ctx context.Context, service domain.IService) HealthCheckResult {
+	result := HealthCheckResult{
+		Name:   service.Name(),
+		Status: HealthStatusUp,
+	}
+
+	if !service.Started() {
+		result.Status = HealthStatusDown
+		result.Error = errors.ErrorServiceNotStarted().Formats(result.Name).Error()
+		return result
+	}
+
+	checker, ok := service.(domain.IHealthChecker)
+	if !ok {
+		return result
+	}
+
+	timeout := defaultHealthTimeout
+	if h.config.Health.TimeoutSeconds > 0 {
+		timeout = time.Duration(h.config.Health.TimeoutSeconds) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	status := make(chan error, 1)
+	go func() {
+		status <- checker.HealthCheck(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-status:
+	case <-ctx.Done():
+		err = errors.ErrorHealthCheckTimeout().Formats(result.Name, timeout)
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
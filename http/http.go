@@ -4,19 +4,26 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/config"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
 	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/middlewares/compression"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/middlewares/cors"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/openapi"
 )
 
 // Http service
@@ -39,12 +46,28 @@ type Http struct {
 	middlewares []domain.IMiddleware
 	// Recovery
 	recovery gin.HandlerFunc
+	// Recovery Policies
+	recoveryPolicies []RecoveryPolicy
+	// Compression Encoders added to the default encoders, by encoding
+	compressionEncoders map[string]compression.EncoderFactory
 	// Additional Config Type
 	additionalConfigType interface{}
 	// Status Channel
 	statusChannel chan error
+	// Failed Channel
+	failed chan error
+	// Unsubscribe stops the hot reload of the configurations
+	unsubscribe func()
+	// Mutex of the configurations that are reloaded
+	mux sync.RWMutex
 	// Started
 	started bool
+	// Open Api document
+	openApi *openapi.Document
+	// Documented routes of the open api document
+	documented []openapi.IDocumented
+	// Capture policy of the bodies
+	capture *capture.Policy
 }
 
 const (
@@ -61,7 +84,8 @@ func New(app domain.IApp, config *httpConfig.Config) *Http {
 		http: &http.Server{
 			Handler: engine,
 		},
-		statusChannel: make(chan error),
+		statusChannel: make(chan error, 1),
+		failed:        make(chan error, 1),
 	}
 
 	newHttp.WithRouter(engine)
@@ -75,9 +99,23 @@ func New(app domain.IApp, config *httpConfig.Config) *Http {
 
 // Name gets the service name
 func (h *Http) Name() string {
+	if h.config == nil {
+		return h.name
+	}
 	return fmt.Sprintf("%s server ready: %d", h.name, h.config.Port)
 }
 
+// DependsOn the server only accepts traffic after every other service, except grpc, is started
+func (h *Http) DependsOn() (services []domain.IService) {
+	for _, s := range h.app.Services() {
+		if s == domain.IService(h) || (h.app.Grpc() != nil && s == domain.IService(h.app.Grpc())) {
+			continue
+		}
+		services = append(services, s)
+	}
+	return services
+}
+
 // Start starts the http service
 func (h *Http) Start() (err error) {
 	if h.config == nil {
@@ -88,6 +126,19 @@ func (h *Http) Start() (err error) {
 			message.ErrorMessage(h.Name(), err)
 			return err
 		}
+
+		// the configurations loaded from the file are reloaded when it changes
+		if h.unsubscribe, err = config.Subscribe(h.ConfigFile(), h.reload); err != nil {
+			// the server works without the hot reload
+			message.ErrorMessage(h.Name(), err)
+			err = nil
+		}
+	}
+
+	// requests metrics, before the recovery so that the panics are measured as server errors
+	if err = h.registerRequestMetrics(); err != nil {
+		message.ErrorMessage(h.Name(), err)
+		return err
 	}
 
 	// recovery
@@ -96,14 +147,32 @@ func (h *Http) Start() (err error) {
 	}
 	h.router.Use(h.recovery)
 
-	// load request info
-	h.router.Use(loadRequestInfo)
+	// cors, before the preflight requests reach the undefined route
+	if h.config.Cors.Enabled {
+		h.router.Use(cors.NewMiddleware(h.app).GetHandlers()...)
+	}
+
+	// load request info, capturing the bodies allowed by the logs and the traces
+	h.capture = h.newCapturePolicy()
+	h.router.Use(h.loadRequestInfo)
 
 	// prometheus meter
 	h.router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// health checks
+	h.registerHealthChecks()
+
+	// compression, before the tracer so that the uncompressed bodies are traced
+	if h.config.Compression.Enabled {
+		opts := []compression.Option{compression.WithConfig(h.config.Compression)}
+		for encoding, factory := range h.compressionEncoders {
+			opts = append(opts, compression.WithEncoder(encoding, factory))
+		}
+		h.router.Use(compression.NewMiddleware(h.app, opts...).GetHandlers()...)
+	}
+
 	// tracer
-	h.router.Use(otelgin.Middleware(h.app.Name())).Use(h.traceRequest)
+	h.router.Use(otelgin.Middleware(h.app.Name())).Use(tracePanics, h.traceRequest)
 
 	// register middlewares
 	for _, middleware := range h.middlewares {
@@ -127,12 +196,24 @@ func (h *Http) Start() (err error) {
 		}
 	}
 
-	h.http.Addr = fmt.Sprintf("%s:%d", h.config.Host, h.config.Port)
+	// openapi document, after every route is registered
+	h.registerOpenApi()
+
+	if err = h.configureServer(); err != nil {
+		message.ErrorMessage(h.Name(), err)
+		return err
+	}
 
 	go func(status chan error) {
-		if err = h.http.ListenAndServe(); err != nil {
-			if err != http.ErrServerClosed {
-				status <- err
+		if errListen := h.listenAndServe(); errListen != nil && errListen != http.ErrServerClosed {
+			// the error is reported to the start or, if it already returned, to the app
+			select {
+			case status <- errListen:
+			default:
+			}
+			select {
+			case h.failed <- errListen:
+			default:
 			}
 		}
 	}(h.statusChannel)
@@ -147,6 +228,42 @@ func (h *Http) Start() (err error) {
 	}
 }
 
+// configureServer applies the address, timeouts and protocols of the server
+func (h *Http) configureServer() (err error) {
+	serverConfig := h.config.Server
+
+	h.http.Addr = fmt.Sprintf("%s:%d", h.config.Host, h.config.Port)
+	h.http.ReadHeaderTimeout = time.Duration(serverConfig.ReadHeaderTimeoutSeconds) * time.Second
+	h.http.ReadTimeout = time.Duration(serverConfig.ReadTimeoutSeconds) * time.Second
+	h.http.WriteTimeout = time.Duration(serverConfig.WriteTimeoutSeconds) * time.Second
+	h.http.IdleTimeout = time.Duration(serverConfig.IdleTimeoutSeconds) * time.Second
+	h.http.MaxHeaderBytes = serverConfig.MaxHeaderBytes
+
+	// the router may have been replaced after the creation of the server
+	h.http.Handler = h.router
+
+	if h.config.Tls.Enabled {
+		if h.http.TLSConfig, err = newTlsConfig(h.config.Tls); err != nil {
+			return err
+		}
+	} else if serverConfig.H2c {
+		h.http.Handler = h2c.NewHandler(h.router, &http2.Server{
+			IdleTimeout: h.http.IdleTimeout,
+		})
+	}
+
+	return nil
+}
+
+// listenAndServe serves the requests, with tls when enabled
+func (h *Http) listenAndServe() error {
+	if h.http.TLSConfig != nil {
+		// the certificates are loaded by the tls configurations
+		return h.http.ListenAndServeTLS("", "")
+	}
+	return h.http.ListenAndServe()
+}
+
 // Config gets the http configurations
 func (h *Http) Config() *httpConfig.Config {
 	return h.config
@@ -159,18 +276,78 @@ func (h *Http) ConfigFile() string {
 
 // Stop stops the http server
 func (h *Http) Stop() (err error) {
+	return h.Shutdown(context.Background())
+}
+
+// Failed gets the channel where the server errors are sent after the start
+func (h *Http) Failed() <-chan error {
+	return h.failed
+}
+
+// ShutdownPhase the http server stops accepting traffic first
+func (h *Http) ShutdownPhase() domain.ShutdownPhase {
+	return domain.ShutdownPhaseTraffic
+}
+
+// Shutdown stops accepting new requests and waits for the active ones until the context is done
+func (h *Http) Shutdown(ctx context.Context) (err error) {
 	if !h.started {
 		return nil
 	}
 	defer close(h.statusChannel)
-	if err = h.http.Shutdown(context.Background()); err != nil {
+
+	h.started = false
+	if h.unsubscribe != nil {
+		h.unsubscribe()
+		h.unsubscribe = nil
+	}
+
+	// the grace period limits the wait for the active requests
+	if h.config.Server.ShutdownGraceSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(h.config.Server.ShutdownGraceSeconds)*time.Second)
+		defer cancel()
+	}
+
+	if err = h.http.Shutdown(ctx); err != nil {
+		// the deadline was exceeded, so the remaining connections are closed
+		_ = h.http.Close()
 		return err
 	}
 
-	h.started = false
 	return nil
 }
 
+// reload applies the api keys of the reloaded configurations
+func (h *Http) reload(cfg *httpConfig.Config, _ []string) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	h.config.ApiKeys = cfg.ApiKeys
+	h.config.ApiKey.Keys = cfg.ApiKey.Keys
+}
+
+// ApiKeys gets the accepted api keys, which are reloaded when the configuration file changes.
+// The keys without name are named by their position and have every scope
+func (h *Http) ApiKeys() []httpConfig.ApiKey {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	if h.config == nil {
+		return nil
+	}
+
+	keys := make([]httpConfig.ApiKey, 0, len(h.config.ApiKeys)+len(h.config.ApiKey.Keys))
+	for i, key := range h.config.ApiKeys {
+		keys = append(keys, httpConfig.ApiKey{
+			Name:   fmt.Sprintf("apiKeys[%d]", i),
+			Key:    key,
+			Scopes: []string{httpConfig.AllScopes},
+		})
+	}
+
+	return append(keys, h.config.ApiKey.Keys...)
+}
+
 // WithMiddleware adds a new controller to the server
 func (h *Http) WithMiddleware(middleware domain.IMiddleware) domain.IHttp {
 	h.middlewares = append(h.middlewares, middleware)
@@ -183,6 +360,12 @@ func (h *Http) WithController(controler domain.IController) domain.IHttp {
 	return h
 }
 
+// WithDocumentation adds the documented routes of the openapi document, for example of a group
+func (h *Http) WithDocumentation(documented openapi.IDocumented) domain.IHttp {
+	h.documented = append(h.documented, documented)
+	return h
+}
+
 // WithRouter sets the router engine
 func (h *Http) WithRouter(router *gin.Engine) domain.IHttp {
 	h.router = router
@@ -203,6 +386,16 @@ func (h *Http) WithRecovery(recovery gin.HandlerFunc) domain.IHttp {
 	return h
 }
 
+// WithCompressionEncoder adds the encoder of an encoding to the compression, for example brotli,
+// that is used when it is in the encodings of the configurations
+func (h *Http) WithCompressionEncoder(encoding string, factory compression.EncoderFactory) domain.IHttp {
+	if h.compressionEncoders == nil {
+		h.compressionEncoders = make(map[string]compression.EncoderFactory)
+	}
+	h.compressionEncoders[encoding] = factory
+	return h
+}
+
 // WithAdditionalConfigType sets an additional config type
 func (h *Http) WithAdditionalConfigType(obj interface{}) domain.IHttp {
 	h.additionalConfigType = obj
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/openapi"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -43,6 +44,22 @@ func (h *HttpMock) Config() *httpConfig.Config {
 	return args.Get(0).(*httpConfig.Config)
 }
 
+func (h *HttpMock) ApiKeys() []httpConfig.ApiKey {
+	args := h.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]httpConfig.ApiKey)
+}
+
+func (h *HttpMock) OpenApi() *openapi.Document {
+	args := h.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*openapi.Document)
+}
+
 func (h *HttpMock) WithMiddleware(controller domain.IMiddleware) domain.IHttp {
 	args := h.Called()
 	if args.Get(0) == nil {
@@ -59,6 +76,14 @@ func (h *HttpMock) WithController(controller domain.IController) domain.IHttp {
 	return args.Get(0).(domain.IHttp)
 }
 
+func (h *HttpMock) WithDocumentation(documented openapi.IDocumented) domain.IHttp {
+	args := h.Called(documented)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(domain.IHttp)
+}
+
 func (h *HttpMock) WithRouter(router *gin.Engine) domain.IHttp {
 	args := h.Called()
 	if args.Get(0) == nil {
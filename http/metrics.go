@@ -0,0 +1,163 @@
+package http
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// metricRequests requests count, exported with the _total suffix
+	metricRequests = "http_server_requests"
+	// metricErrors server errors count, exported with the _total suffix
+	metricErrors = "http_server_errors"
+	// metricInFlight requests in flight
+	metricInFlight = "http_server_requests_in_flight"
+	// metricDuration requests latency, exported with the _seconds suffix
+	metricDuration = "http_server_request_duration"
+	// metricRequestSize requests body size, exported with the _bytes suffix
+	metricRequestSize = "http_server_request_size"
+	// metricResponseSize responses body size, exported with the _bytes suffix
+	metricResponseSize = "http_server_response_size"
+
+	// metricLabelMethod method label
+	metricLabelMethod = "method"
+	// metricLabelRoute route template label
+	metricLabelRoute = "route"
+	// metricLabelStatusClass status class label (2xx, 4xx, ...)
+	metricLabelStatusClass = "status_class"
+	// unmatchedRoute route of the requests without route, to keep the cardinality of the labels
+	unmatchedRoute = "unmatched"
+)
+
+// requestMetrics metrics of the requests (rate, errors and duration)
+type requestMetrics struct {
+	requests     metric.Int64Counter
+	errors       metric.Int64Counter
+	inFlight     metric.Int64UpDownCounter
+	duration     metric.Float64Histogram
+	requestSize  metric.Int64Histogram
+	responseSize metric.Int64Histogram
+	excluded     map[string]bool
+}
+
+// newRequestMetrics creates the instruments of the requests metrics
+func (h *Http) newRequestMetrics(meter metric.Meter) (m *requestMetrics, err error) {
+	cfg := h.config.Metrics
+	m = &requestMetrics{excluded: make(map[string]bool)}
+	for _, route := range cfg.ExcludeRoutes {
+		m.excluded[route] = true
+	}
+
+	if m.requests, err = meter.Int64Counter(metricRequests,
+		metric.WithDescription("Number of HTTP requests")); err != nil {
+		return nil, err
+	}
+
+	if m.errors, err = meter.Int64Counter(metricErrors,
+		metric.WithDescription("Number of HTTP requests with server errors")); err != nil {
+		return nil, err
+	}
+
+	if m.inFlight, err = meter.Int64UpDownCounter(metricInFlight,
+		metric.WithDescription("Number of HTTP requests in flight")); err != nil {
+		return nil, err
+	}
+
+	// the histograms without buckets use the default buckets of the provider
+	durationOptions := []metric.Float64HistogramOption{metric.WithUnit("s")}
+	if len(cfg.DurationBuckets) > 0 {
+		durationOptions = append(durationOptions, metric.WithExplicitBucketBoundaries(cfg.DurationBuckets...))
+	}
+	sizeOptions := []metric.Int64HistogramOption{metric.WithUnit("By")}
+	if len(cfg.SizeBuckets) > 0 {
+		sizeOptions = append(sizeOptions, metric.WithExplicitBucketBoundaries(cfg.SizeBuckets...))
+	}
+
+	if m.duration, err = meter.Float64Histogram(metricDuration,
+		append([]metric.Float64HistogramOption{metric.WithDescription("Duration of the HTTP requests")}, durationOptions...)...); err != nil {
+		return nil, err
+	}
+
+	if m.requestSize, err = meter.Int64Histogram(metricRequestSize,
+		append([]metric.Int64HistogramOption{metric.WithDescription("Size of the HTTP requests body")}, sizeOptions...)...); err != nil {
+		return nil, err
+	}
+
+	if m.responseSize, err = meter.Int64Histogram(metricResponseSize,
+		append([]metric.Int64HistogramOption{metric.WithDescription("Size of the HTTP responses body")}, sizeOptions...)...); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// handle records the metrics of a request
+func (m *requestMetrics) handle(gCtx *gin.Context) {
+	route := gCtx.FullPath()
+	if m.excluded[route] || (route == "" && m.excluded[gCtx.Request.URL.Path]) {
+		gCtx.Next()
+		return
+	}
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	ctx := gCtx.Request.Context()
+	routeAttrs := metric.WithAttributes(
+		attribute.String(metricLabelMethod, gCtx.Request.Method),
+		attribute.String(metricLabelRoute, route),
+	)
+
+	m.inFlight.Add(ctx, 1, routeAttrs)
+	start := time.Now()
+
+	defer func() {
+		m.inFlight.Add(ctx, -1, routeAttrs)
+
+		status := gCtx.Writer.Status()
+		attrs := metric.WithAttributes(
+			attribute.String(metricLabelMethod, gCtx.Request.Method),
+			attribute.String(metricLabelRoute, route),
+			attribute.String(metricLabelStatusClass, fmt.Sprintf("%dxx", status/100)),
+		)
+
+		m.requests.Add(ctx, 1, attrs)
+		if status >= 500 {
+			m.errors.Add(ctx, 1, attrs)
+		}
+		m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+
+		requestSize := gCtx.Request.ContentLength
+		if requestSize < 0 {
+			requestSize = 0
+		}
+		m.requestSize.Record(ctx, requestSize, attrs)
+
+		responseSize := gCtx.Writer.Size()
+		if responseSize < 0 {
+			responseSize = 0
+		}
+		m.responseSize.Record(ctx, int64(responseSize), attrs)
+	}()
+
+	gCtx.Next()
+}
+
+// registerRequestMetrics registers the metrics of the requests in every route, through the meter service
+func (h *Http) registerRequestMetrics() error {
+	if h.config.Metrics.Disabled || h.app.Meter() == nil || h.app.Meter().Prometheus() == nil {
+		return nil
+	}
+
+	m, err := h.newRequestMetrics(h.app.Meter().Prometheus())
+	if err != nil {
+		return err
+	}
+
+	h.router.Use(m.handle)
+	return nil
+}
@@ -0,0 +1,141 @@
+package apikey
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/errors"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/response"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/tracer"
+)
+
+const (
+	// defaultHeader header with the key when it is not configured
+	defaultHeader = "X-Api-Key"
+)
+
+// Middleware authenticates the requests with the api keys of the http configurations
+type Middleware struct {
+	// App
+	app domain.IApp
+	// Scopes required to the key
+	scopes []string
+}
+
+// Option option of the middleware
+type Option func(m *Middleware)
+
+// WithScopes sets the scopes that the key must be allowed to, for example the scopes of a group or an endpoint
+func WithScopes(scopes ...string) Option {
+	return func(m *Middleware) {
+		m.scopes = append(m.scopes, scopes...)
+	}
+}
+
+// NewMiddleware creates a new api key middleware
+func NewMiddleware(app domain.IApp, opts ...Option) *Middleware {
+	m := &Middleware{
+		app: app,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// RegisterMiddlewares registers the middleware in every route
+func (m *Middleware) RegisterMiddlewares() {
+	m.app.Http().Router().Use(m.GetHandlers()...)
+}
+
+// GetHandlers gets the handlers of the middleware
+func (m *Middleware) GetHandlers() []gin.HandlerFunc {
+	return []gin.HandlerFunc{m.handle}
+}
+
+// handle authenticates a request
+func (m *Middleware) handle(ctx *gin.Context) {
+	key, ok := m.find(m.getKey(ctx))
+	if !ok {
+		m.abort(ctx, http.StatusUnauthorized, errorCodes.ErrorInvalidApiKey())
+		return
+	}
+
+	for _, scope := range m.scopes {
+		if !allowed(key, scope) {
+			m.abort(ctx, http.StatusForbidden, errorCodes.ErrorApiKeyScopeNotAllowed().Formats(key.Name, scope))
+			return
+		}
+	}
+
+	// the name of the key identifies the integration in the logs and traces
+	ctx.Set(contextInfra.CtxApiKeyName, key.Name)
+	trace.SpanFromContext(ctx.Request.Context()).SetAttributes(attribute.String(tracer.TracerTagApiKeyName, key.Name))
+
+	ctx.Next()
+}
+
+// getKey gets the key from the header or, if configured, from the query param
+func (m *Middleware) getKey(ctx *gin.Context) string {
+	header, queryParam := defaultHeader, ""
+	if cfg := m.app.Http().Config(); cfg != nil {
+		if cfg.ApiKey.Header != "" {
+			header = cfg.ApiKey.Header
+		}
+		queryParam = cfg.ApiKey.QueryParam
+	}
+
+	if key := ctx.GetHeader(header); key != "" {
+		return key
+	}
+
+	if queryParam != "" {
+		return ctx.Query(queryParam)
+	}
+
+	return ""
+}
+
+// find finds the api key, comparing every key in constant time
+func (m *Middleware) find(value string) (key httpConfig.ApiKey, ok bool) {
+	if value == "" {
+		return key, false
+	}
+
+	// the hashes have the same length, so the comparison does not leak the length of the keys
+	hash := sha256.Sum256([]byte(value))
+	for _, apiKey := range m.app.Http().ApiKeys() {
+		apiKeyHash := sha256.Sum256([]byte(apiKey.Key))
+		if subtle.ConstantTimeCompare(hash[:], apiKeyHash[:]) == 1 && !ok {
+			key, ok = apiKey, true
+		}
+	}
+
+	return key, ok
+}
+
+// allowed checks if a key is allowed to a scope
+func allowed(key httpConfig.ApiKey, scope string) bool {
+	for _, s := range key.Scopes {
+		if s == scope || s == httpConfig.AllScopes {
+			return true
+		}
+	}
+	return false
+}
+
+// abort aborts the request with an error
+func (m *Middleware) abort(ctx *gin.Context, status int, err errors.ErrorDetails) {
+	ctx.AbortWithStatusJSON(response.GetResponse(nil, nil, nil, err.SetStatusCode(status)))
+}
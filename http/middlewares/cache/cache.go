@@ -0,0 +1,400 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/cache"
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfNoneMatch = "If-None-Match"
+	headerAge         = "Age"
+	headerVary        = "Vary"
+	headerSetCookie   = "Set-Cookie"
+	headerCache       = "X-Cache"
+	headerControl     = "Cache-Control"
+	headerAuth        = "Authorization"
+	headerCookie      = "Cookie"
+	headerApiKey      = "X-Api-Key"
+
+	directiveNoStore = "no-store"
+	directiveNoCache = "no-cache"
+	directivePrivate = "private"
+	directiveMaxAge  = "max-age"
+
+	cacheHit  = "HIT"
+	cacheMiss = "MISS"
+)
+
+// excludedHeaders headers of a request that are not stored, since they are set for every request
+var excludedHeaders = []string{
+	"Content-Length",
+	"Date",
+	"Connection",
+	"Transfer-Encoding",
+	"Retry-After",
+	headerSetCookie,
+	headerAge,
+	headerCache,
+	contextInfra.HeaderRequestId,
+}
+
+// excludedHeaderPrefixes prefixes of the headers that are not stored, since they are set by other middlewares
+var excludedHeaderPrefixes = []string{
+	"Access-Control-",
+	"Ratelimit-",
+}
+
+// Middleware caches the successful responses of the GET requests, for example of an endpoint.
+// The responses are keyed by the route, the query and the selected headers and claims.
+// The authenticated requests (authorization, api key or cookie) are only cached when the key identifies the client, with WithKey
+type Middleware struct {
+	// App
+	app domain.IApp
+	// Ttl of the responses
+	ttl time.Duration
+	// Headers that are part of the key
+	headers []string
+	// Keys get the additional parts of the key of a request
+	keys []KeyFunc
+	// Tags that invalidate the responses
+	tags []string
+	// Tags Func gets the tags of a request, for example with the path parameters
+	tagsFunc func(ctx *gin.Context) []string
+	// Store
+	store cache.IStore
+	// Max Body Bytes
+	maxBodyBytes int
+	// Loaded the configurations
+	loaded bool
+	// Mutex
+	mux sync.Mutex
+}
+
+// Option option of the middleware
+type Option func(m *Middleware)
+
+// WithHeaders sets the headers that are part of the key, which are also added to the vary header
+func WithHeaders(headers ...string) Option {
+	return func(m *Middleware) {
+		for _, header := range headers {
+			m.headers = append(m.headers, http.CanonicalHeaderKey(header))
+		}
+	}
+}
+
+// WithKey sets the additional parts of the key of the requests, for example WithKey(ByJwtSubject)
+func WithKey(keys ...KeyFunc) Option {
+	return func(m *Middleware) {
+		m.keys = keys
+	}
+}
+
+// WithTags sets the tags that invalidate the responses
+func WithTags(tags ...string) Option {
+	return func(m *Middleware) {
+		m.tags = append(m.tags, tags...)
+	}
+}
+
+// WithTagsFunc sets the function that gets the tags of a request, for example "country:" + ctx.Param("id")
+func WithTagsFunc(tagsFunc func(ctx *gin.Context) []string) Option {
+	return func(m *Middleware) {
+		m.tagsFunc = tagsFunc
+	}
+}
+
+// WithStore sets the store, instead of creating it with the http configurations
+func WithStore(store cache.IStore) Option {
+	return func(m *Middleware) {
+		m.store = store
+	}
+}
+
+// NewMiddleware creates a new cache middleware, that caches the responses during the ttl
+func NewMiddleware(app domain.IApp, ttl time.Duration, opts ...Option) *Middleware {
+	m := &Middleware{
+		app: app,
+		ttl: ttl,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// RegisterMiddlewares registers the middleware in every route
+func (m *Middleware) RegisterMiddlewares() {
+	m.app.Http().Router().Use(m.GetHandlers()...)
+}
+
+// GetHandlers gets the handlers of the middleware
+func (m *Middleware) GetHandlers() []gin.HandlerFunc {
+	return []gin.HandlerFunc{m.handle}
+}
+
+// Store gets the store, that is created with the http configurations after the http service starts.
+// Without store and redis, the store is nil and the responses are not cached
+func (m *Middleware) Store() cache.IStore {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if !m.loaded {
+		var config httpConfig.CacheConfig
+		if cfg := m.app.Http().Config(); cfg != nil {
+			config = cfg.Cache
+		}
+
+		m.maxBodyBytes = config.MaxBodyBytes
+		if m.store == nil && m.app.Redis() != nil {
+			m.store = cache.NewRedisStore(m.app.Redis(), config.Prefix)
+		}
+		m.loaded = true
+	}
+
+	return m.store
+}
+
+// Invalidate removes the cached responses of the tags
+func (m *Middleware) Invalidate(ctx context.Context, tags ...string) error {
+	store := m.Store()
+	if store == nil {
+		return nil
+	}
+	return store.Invalidate(ctx, tags...)
+}
+
+// handle answers a request with the cached response, or caches the response
+func (m *Middleware) handle(ctx *gin.Context) {
+	if ctx.Request.Method != http.MethodGet {
+		ctx.Next()
+		return
+	}
+
+	// the responses of the authenticated requests are not shared between the clients
+	if len(m.keys) == 0 && m.authenticated(ctx) {
+		ctx.Next()
+		return
+	}
+
+	store := m.Store()
+	if store == nil {
+		ctx.Next()
+		return
+	}
+
+	key := m.key(ctx)
+
+	// the stored response is skipped when the client asks for a fresh one
+	var entry *cache.Entry
+	if !revalidate(ctx.Request.Header) {
+		var err error
+		entry, err = store.Get(ctx.Request.Context(), key)
+		if err != nil {
+			// the requests are answered without the cache when it is unavailable
+			_ = ctx.Error(err)
+		}
+	}
+
+	if entry != nil {
+		m.reply(ctx, ctx.Writer, entry, cacheHit)
+		ctx.Abort()
+		return
+	}
+
+	w := &writer{
+		ResponseWriter: ctx.Writer,
+		maxBytes:       m.maxBodyBytes,
+	}
+	ctx.Writer = w
+
+	// the writer is restored when panicking, discarding the buffered body so that the recovery replies with the error
+	defer func() {
+		ctx.Writer = w.ResponseWriter
+	}()
+
+	ctx.Next()
+
+	if w.passthrough {
+		return
+	}
+
+	if !m.cacheable(ctx, w) {
+		_ = w.bypass()
+		return
+	}
+
+	entry = &cache.Entry{
+		Status:   w.Status(),
+		Header:   storedHeader(w.Header()),
+		Body:     w.body.Bytes(),
+		ETag:     w.Header().Get(headerETag),
+		StoredAt: time.Now(),
+	}
+	if entry.ETag == "" {
+		entry.ETag = cache.NewETag(entry.Body)
+	}
+
+	if err := store.Set(ctx.Request.Context(), key, entry, m.ttl, m.requestTags(ctx)...); err != nil {
+		_ = ctx.Error(err)
+	}
+
+	m.reply(ctx, w.ResponseWriter, entry, cacheMiss)
+}
+
+// reply writes a response, or not modified when the client has the entity tag
+func (m *Middleware) reply(ctx *gin.Context, w gin.ResponseWriter, entry *cache.Entry, cacheStatus string) {
+	header := w.Header()
+	for name, values := range entry.Header {
+		header[name] = values
+	}
+	header.Set(headerETag, entry.ETag)
+	header.Set(headerCache, cacheStatus)
+	if cacheStatus == cacheHit {
+		header.Set(headerAge, strconv.Itoa(int(time.Since(entry.StoredAt).Seconds())))
+	}
+	for _, name := range m.headers {
+		header.Add(headerVary, name)
+	}
+
+	if cache.MatchETag(ctx.GetHeader(headerIfNoneMatch), entry.ETag) {
+		header.Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+		w.WriteHeaderNow()
+		return
+	}
+
+	w.WriteHeader(entry.Status)
+	_, _ = w.Write(entry.Body)
+}
+
+// cacheable checks if a response is cached, respecting the cache control of the request and the response
+func (m *Middleware) cacheable(ctx *gin.Context, w *writer) bool {
+	return w.Status() == http.StatusOK &&
+		len(ctx.Errors) == 0 &&
+		w.Header().Get(headerSetCookie) == "" &&
+		!hasDirective(ctx.Request.Header, directiveNoStore) &&
+		!hasDirective(w.Header(), directiveNoStore, directivePrivate)
+}
+
+// authenticated checks if a request has credentials, in the authorization, the api key or the cookies
+func (m *Middleware) authenticated(ctx *gin.Context) bool {
+	if ctx.GetHeader(headerAuth) != "" || ctx.GetHeader(headerCookie) != "" {
+		return true
+	}
+
+	header, queryParam := headerApiKey, ""
+	if cfg := m.app.Http().Config(); cfg != nil {
+		if cfg.ApiKey.Header != "" {
+			header = cfg.ApiKey.Header
+		}
+		queryParam = cfg.ApiKey.QueryParam
+	}
+
+	return ctx.GetHeader(header) != "" || (queryParam != "" && ctx.Query(queryParam) != "")
+}
+
+// revalidate checks if the cache control of a request asks for a fresh response (no-cache, no-store or max-age=0)
+func revalidate(header http.Header) bool {
+	return hasDirective(header, directiveNoCache, directiveNoStore) || directiveValue(header, directiveMaxAge) == "0"
+}
+
+// hasDirective checks if the cache control of the headers has any of the directives
+func hasDirective(header http.Header, directives ...string) bool {
+	for _, directive := range directives {
+		if _, ok := findDirective(header, directive); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// directiveValue gets the value of a directive of the cache control of the headers
+func directiveValue(header http.Header, directive string) string {
+	value, _ := findDirective(header, directive)
+	return value
+}
+
+// findDirective finds a directive of the cache control of the headers, with its value
+func findDirective(header http.Header, directive string) (string, bool) {
+	for _, value := range header.Values(headerControl) {
+		for _, part := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if strings.EqualFold(strings.TrimSpace(name), directive) {
+				return strings.Trim(strings.TrimSpace(arg), `"`), true
+			}
+		}
+	}
+	return "", false
+}
+
+// key gets the key of a request, with the route, the query, the headers and the additional parts
+func (m *Middleware) key(ctx *gin.Context) string {
+	route := ctx.FullPath()
+	if route == "" {
+		route = ctx.Request.URL.Path
+	}
+
+	parts := []string{ctx.Request.Method, route, ctx.Request.URL.Path, ctx.Request.URL.Query().Encode()}
+	for _, header := range m.headers {
+		parts = append(parts, header+"="+strings.Join(ctx.Request.Header.Values(header), ","))
+	}
+	for _, keyFunc := range m.keys {
+		parts = append(parts, keyFunc(ctx))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestTags gets the tags of a request
+func (m *Middleware) requestTags(ctx *gin.Context) []string {
+	tags := append([]string{}, m.tags...)
+	if m.tagsFunc != nil {
+		tags = append(tags, m.tagsFunc(ctx)...)
+	}
+	return tags
+}
+
+// storedHeader gets the headers of a response that are stored
+func storedHeader(header http.Header) http.Header {
+	stored := make(http.Header, len(header))
+	for name, values := range header {
+		if excludedHeader(name) {
+			continue
+		}
+		stored[name] = append([]string{}, values...)
+	}
+	return stored
+}
+
+// excludedHeader checks if a header is not stored
+func excludedHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	for _, excluded := range excludedHeaders {
+		if name == http.CanonicalHeaderKey(excluded) {
+			return true
+		}
+	}
+	for _, prefix := range excludedHeaderPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
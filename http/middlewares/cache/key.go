@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"github.com/gin-gonic/gin"
+
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/middlewares/jwt"
+)
+
+// KeyFunc gets a part of the key of a request, in addition to the route and the query
+type KeyFunc func(ctx *gin.Context) string
+
+// ByApiKey gets the name of the api key, set by the api key middleware
+func ByApiKey(ctx *gin.Context) string {
+	return "apiKey:" + ctx.GetString(contextInfra.CtxApiKeyName)
+}
+
+// ByJwtSubject gets the subject of the token, set by the jwt middleware
+func ByJwtSubject(ctx *gin.Context) string {
+	return ByClaims(func(claims jwt.IClaims) string {
+		return claims.Registered().Subject
+	})(ctx)
+}
+
+// ByClaims gets a part of the key from the claims of the token, set by the jwt middleware, for example the role
+func ByClaims(claimsFunc func(claims jwt.IClaims) string) KeyFunc {
+	return func(ctx *gin.Context) string {
+		if value, exists := ctx.Get(jwt.ClaimsKey); exists {
+			if claims, ok := value.(jwt.IClaims); ok && claims.Registered() != nil {
+				return "claims:" + claimsFunc(claims)
+			}
+		}
+		return "claims:"
+	}
+}
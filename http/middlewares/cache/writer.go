@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"bytes"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writer buffers the body until the end of the request, so that the entity tag is set before the headers
+type writer struct {
+	gin.ResponseWriter
+	// Body buffered
+	body bytes.Buffer
+	// Max Bytes buffered
+	maxBytes int
+	// Passthrough writes the body without buffering, when it is too large or streamed
+	passthrough bool
+}
+
+// Write buffers or writes the body
+func (w *writer) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+
+	if w.body.Len()+len(data) > w.maxBytes {
+		if err := w.bypass(); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	return w.body.Write(data)
+}
+
+// WriteString buffers or writes the body
+func (w *writer) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow writes the headers, so the response is not cached
+func (w *writer) WriteHeaderNow() {
+	_ = w.bypass()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush flushes the response, so the response is not cached
+func (w *writer) Flush() {
+	_ = w.bypass()
+	w.ResponseWriter.Flush()
+}
+
+// bypass writes the buffered body and stops buffering
+func (w *writer) bypass() error {
+	if w.passthrough {
+		return nil
+	}
+	w.passthrough = true
+
+	if w.body.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.body.Bytes())
+	w.body.Reset()
+	return err
+}
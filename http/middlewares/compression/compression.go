@@ -0,0 +1,248 @@
+package compression
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
+	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+)
+
+// Middleware compresses the responses with the encodings accepted by the clients.
+// It must be registered before the capture of the responses, so that the uncompressed bodies are captured
+type Middleware struct {
+	// App
+	app domain.IApp
+	// Config of every route, instead of the http configurations
+	config *httpConfig.CompressionConfig
+	// Factories of the encoders, by encoding
+	factories map[string]EncoderFactory
+	// Settings loaded from the configurations
+	settings *settings
+	// Mutex
+	mux sync.Mutex
+}
+
+// settings settings of the compression, loaded after the http service starts
+type settings struct {
+	// Encodings supported, by the order of preference
+	encodings []string
+	// Pools of encoders, by encoding
+	pools map[string]*sync.Pool
+	// Min Bytes of the responses that are compressed
+	minBytes int
+	// Content Types compressed
+	contentTypes []string
+}
+
+// Option option of the middleware
+type Option func(m *Middleware)
+
+// WithConfig sets the configurations of every route, instead of the http configurations
+func WithConfig(config httpConfig.CompressionConfig) Option {
+	return func(m *Middleware) {
+		m.config = &config
+	}
+}
+
+// WithEncoder adds the encoder of an encoding, for example brotli, that is used when it is in the encodings
+func WithEncoder(encoding string, factory EncoderFactory) Option {
+	return func(m *Middleware) {
+		m.factories[strings.ToLower(encoding)] = factory
+	}
+}
+
+// NewMiddleware creates a new compression middleware
+func NewMiddleware(app domain.IApp, opts ...Option) *Middleware {
+	m := &Middleware{
+		app:       app,
+		factories: make(map[string]EncoderFactory),
+	}
+
+	for encoding, factory := range defaultEncoders {
+		m.factories[encoding] = factory
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// RegisterMiddlewares registers the middleware in every route
+func (m *Middleware) RegisterMiddlewares() {
+	m.app.Http().Router().Use(m.GetHandlers()...)
+}
+
+// GetHandlers gets the handlers of the middleware
+func (m *Middleware) GetHandlers() []gin.HandlerFunc {
+	return []gin.HandlerFunc{m.handle}
+}
+
+// handle compresses the response of a request
+func (m *Middleware) handle(ctx *gin.Context) {
+	s := m.getSettings()
+
+	encoding := s.negotiate(ctx.GetHeader(headerAcceptEncoding))
+	if encoding == "" || ctx.Request.Method == http.MethodHead {
+		ctx.Next()
+		return
+	}
+
+	w := &writer{
+		ResponseWriter: ctx.Writer,
+		settings:       s,
+		encoding:       encoding,
+	}
+	ctx.Writer = w
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			// the buffered body is discarded, so that the recovery replies with the error
+			w.discard()
+			ctx.Writer = w.ResponseWriter
+			panic(recovered)
+		}
+		w.close()
+		ctx.Writer = w.ResponseWriter
+	}()
+
+	ctx.Next()
+}
+
+// getSettings loads the settings of the options and of the http configurations, after the http service starts
+func (m *Middleware) getSettings() *settings {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.settings != nil {
+		return m.settings
+	}
+
+	var config httpConfig.CompressionConfig
+	if m.config != nil {
+		config = *m.config
+	} else if cfg := m.app.Http().Config(); cfg != nil {
+		config = cfg.Compression
+	}
+
+	level := config.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	s := &settings{
+		pools:        make(map[string]*sync.Pool),
+		minBytes:     config.MinBytes,
+		contentTypes: config.ContentTypes,
+	}
+
+	for _, encoding := range config.Encodings {
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+		factory, ok := m.factories[encoding]
+		if !ok {
+			// the encodings without encoder are not negotiated
+			message.ErrorMessage(fmt.Sprintf("Compression encoding %s", encoding), errors.New("no encoder, add it with WithEncoder or WithCompressionEncoder of the http service"))
+			continue
+		}
+
+		s.encodings = append(s.encodings, encoding)
+		s.pools[encoding] = &sync.Pool{
+			New: func() any {
+				encoder, err := factory(io.Discard, level)
+				if err != nil {
+					return nil
+				}
+				return encoder
+			},
+		}
+	}
+
+	m.settings = s
+	return s
+}
+
+// negotiate gets the supported encoding with the highest quality of the accept encoding header
+func (s *settings) negotiate(acceptEncoding string) string {
+	if acceptEncoding == "" || len(s.encodings) == 0 {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		qualities[strings.ToLower(strings.TrimSpace(name))] = quality
+	}
+
+	candidates := make([]string, 0, len(s.encodings))
+	for _, encoding := range s.encodings {
+		if quality(qualities, encoding) > 0 {
+			candidates = append(candidates, encoding)
+		}
+	}
+
+	// the order of preference breaks the ties of the quality
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return quality(qualities, candidates[i]) > quality(qualities, candidates[j])
+	})
+
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[0]
+}
+
+// quality gets the quality of an encoding, or of the wildcard when the encoding is not listed
+func quality(qualities map[string]float64, encoding string) float64 {
+	if q, ok := qualities[encoding]; ok {
+		return q
+	}
+	return qualities["*"]
+}
+
+// compressible checks if a content type is compressed
+func (s *settings) compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return capture.MatchContentType(s.contentTypes, mediaType)
+}
+
+// getEncoder gets an encoder of the pool, that writes to w
+func (s *settings) getEncoder(encoding string, w io.Writer) Encoder {
+	encoder, ok := s.pools[encoding].Get().(Encoder)
+	if !ok {
+		return nil
+	}
+	encoder.Reset(w)
+	return encoder
+}
+
+// putEncoder returns an encoder to the pool
+func (s *settings) putEncoder(encoding string, encoder Encoder) {
+	encoder.Reset(io.Discard)
+	s.pools[encoding].Put(encoder)
+}
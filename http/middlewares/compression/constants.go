@@ -0,0 +1,18 @@
+package compression
+
+const (
+	// EncodingGzip gzip encoding
+	EncodingGzip = "gzip"
+	// EncodingDeflate deflate encoding
+	EncodingDeflate = "deflate"
+
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerContentEncoding = "Content-Encoding"
+	headerContentLength   = "Content-Length"
+	headerContentType     = "Content-Type"
+	headerContentRange    = "Content-Range"
+	headerVary            = "Vary"
+	headerETag            = "ETag"
+
+	weakETagPrefix = "W/"
+)
@@ -0,0 +1,30 @@
+package compression
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+)
+
+// Encoder compresses the responses of an encoding
+type Encoder interface {
+	io.WriteCloser
+	// Flush writes the pending compressed data
+	Flush() error
+	// Reset discards the state of the encoder, which then writes to w
+	Reset(w io.Writer)
+}
+
+// EncoderFactory creates an encoder with a compression level, where -1 is the default level of the encoder
+type EncoderFactory func(w io.Writer, level int) (Encoder, error)
+
+// defaultEncoders encoders supported without options
+var defaultEncoders = map[string]EncoderFactory{
+	EncodingGzip: func(w io.Writer, level int) (Encoder, error) {
+		return gzip.NewWriterLevel(w, level)
+	},
+	// the deflate content coding is the zlib format, with its header and checksum
+	EncodingDeflate: func(w io.Writer, level int) (Encoder, error) {
+		return zlib.NewWriterLevel(w, level)
+	},
+}
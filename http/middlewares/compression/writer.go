@@ -0,0 +1,149 @@
+package compression
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writer buffers the body until it reaches the min bytes, and then compresses it when the content type is compressed
+type writer struct {
+	gin.ResponseWriter
+	// Settings
+	settings *settings
+	// Encoding negotiated
+	encoding string
+	// Buffer of the body before the decision
+	buffer []byte
+	// Decided if the body is compressed
+	decided bool
+	// Encoder of the compressed body
+	encoder Encoder
+}
+
+// Write buffers or writes the body
+func (w *writer) Write(data []byte) (int, error) {
+	if w.decided {
+		return w.write(data)
+	}
+
+	w.buffer = append(w.buffer, data...)
+	if len(w.buffer) < w.settings.minBytes {
+		return len(data), nil
+	}
+
+	w.decide()
+	if err := w.flushBuffer(); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// WriteString buffers or writes the body
+func (w *writer) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow decides the compression and writes the headers
+func (w *writer) WriteHeaderNow() {
+	if !w.decided {
+		w.decide()
+		_ = w.flushBuffer()
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush decides the compression and flushes the response, so that the streams are not buffered
+func (w *writer) Flush() {
+	if !w.decided {
+		w.decide()
+	}
+	_ = w.flushBuffer()
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// close writes the remaining body and returns the encoder to the pool
+func (w *writer) close() {
+	if !w.decided {
+		w.decide()
+	}
+	_ = w.flushBuffer()
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+		w.settings.putEncoder(w.encoding, w.encoder)
+		w.encoder = nil
+	}
+}
+
+// discard discards the buffered body and returns the encoder to the pool
+func (w *writer) discard() {
+	w.buffer = nil
+	if w.encoder != nil {
+		w.settings.putEncoder(w.encoding, w.encoder)
+		w.encoder = nil
+	}
+}
+
+// decide decides if the body is compressed, by the status, the headers and the body buffered
+func (w *writer) decide() {
+	w.decided = true
+
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return
+	}
+
+	header := w.Header()
+	if header.Get(headerContentEncoding) != "" || header.Get(headerContentRange) != "" {
+		return
+	}
+
+	contentType := header.Get(headerContentType)
+	if contentType == "" && len(w.buffer) > 0 {
+		// the content type is detected before compressing, since it can not be detected afterwards
+		contentType = http.DetectContentType(w.buffer)
+		header.Set(headerContentType, contentType)
+	}
+
+	if !w.settings.compressible(contentType) {
+		return
+	}
+	header.Add(headerVary, headerAcceptEncoding)
+
+	if len(w.buffer) < w.settings.minBytes {
+		return
+	}
+
+	if w.encoder = w.settings.getEncoder(w.encoding, w.ResponseWriter); w.encoder == nil {
+		return
+	}
+	header.Set(headerContentEncoding, w.encoding)
+	header.Del(headerContentLength)
+
+	// the compressed body is not byte for byte the same as the identity body, so their entity tag is weak
+	if etag := header.Get(headerETag); etag != "" && !strings.HasPrefix(etag, weakETagPrefix) {
+		header.Set(headerETag, weakETagPrefix+etag)
+	}
+}
+
+// flushBuffer writes the buffered body
+func (w *writer) flushBuffer() error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+	_, err := w.write(w.buffer)
+	w.buffer = nil
+	return err
+}
+
+// write writes the body, compressed when there is an encoder
+func (w *writer) write(data []byte) (int, error) {
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
@@ -0,0 +1,19 @@
+package cors
+
+const (
+	// AllOrigins origin that allows every origin
+	AllOrigins = "*"
+	// RegexPrefix prefix of the origins that are regular expressions
+	RegexPrefix = "regex:"
+
+	headerOrigin           = "Origin"
+	headerVary             = "Vary"
+	headerRequestMethod    = "Access-Control-Request-Method"
+	headerRequestHeaders   = "Access-Control-Request-Headers"
+	headerAllowOrigin      = "Access-Control-Allow-Origin"
+	headerAllowMethods     = "Access-Control-Allow-Methods"
+	headerAllowHeaders     = "Access-Control-Allow-Headers"
+	headerAllowCredentials = "Access-Control-Allow-Credentials"
+	headerExposeHeaders    = "Access-Control-Expose-Headers"
+	headerMaxAge           = "Access-Control-Max-Age"
+)
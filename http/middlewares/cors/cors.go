@@ -0,0 +1,172 @@
+package cors
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/errors"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/response"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+)
+
+// Middleware answers the preflight requests and sets the cors headers of the requests.
+// It must be registered in every route, since the preflight requests do not match the routes of the groups
+type Middleware struct {
+	// App
+	app domain.IApp
+	// Policy of every route, instead of the policy of the http configurations
+	policy *httpConfig.CorsPolicy
+	// Groups policies of the groups, added to the policies of the http configurations
+	groups []httpConfig.CorsGroupPolicy
+	// Policies compiled, sorted from the longest path
+	policies []*policy
+	// Mutex
+	mux sync.Mutex
+}
+
+// Option option of the middleware
+type Option func(m *Middleware)
+
+// WithPolicy sets the policy of every route, instead of the policy of the http configurations
+func WithPolicy(policy httpConfig.CorsPolicy) Option {
+	return func(m *Middleware) {
+		m.policy = &policy
+	}
+}
+
+// WithGroupPolicy sets the policy of a group, for example WithGroupPolicy(group.String(), policy)
+func WithGroupPolicy(path string, policy httpConfig.CorsPolicy) Option {
+	return func(m *Middleware) {
+		m.groups = append(m.groups, httpConfig.CorsGroupPolicy{Path: path, Policy: policy})
+	}
+}
+
+// NewMiddleware creates a new cors middleware
+func NewMiddleware(app domain.IApp, opts ...Option) *Middleware {
+	m := &Middleware{
+		app: app,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// RegisterMiddlewares registers the middleware in every route
+func (m *Middleware) RegisterMiddlewares() {
+	m.app.Http().Router().Use(m.GetHandlers()...)
+}
+
+// GetHandlers gets the handlers of the middleware
+func (m *Middleware) GetHandlers() []gin.HandlerFunc {
+	return []gin.HandlerFunc{m.handle}
+}
+
+// handle sets the cors headers of a request
+func (m *Middleware) handle(ctx *gin.Context) {
+	origin := ctx.GetHeader(headerOrigin)
+	if origin == "" {
+		ctx.Next()
+		return
+	}
+
+	policies, err := m.getPolicies()
+	if err != nil {
+		m.abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	p := m.find(policies, ctx.Request.URL.Path)
+	preflight := ctx.Request.Method == http.MethodOptions && ctx.GetHeader(headerRequestMethod) != ""
+
+	if p == nil || !p.allowed(origin) {
+		if preflight {
+			m.abort(ctx, http.StatusForbidden, errorCodes.ErrorCorsOriginNotAllowed().Formats(origin))
+			return
+		}
+		// the browser blocks the response without the cors headers
+		ctx.Next()
+		return
+	}
+
+	header := ctx.Writer.Header()
+	p.setHeaders(header, origin)
+
+	// the preflight requests are answered before the routes, since they do not have routes
+	if preflight {
+		p.setPreflightHeaders(header, ctx.GetHeader(headerRequestHeaders))
+		ctx.AbortWithStatus(http.StatusNoContent)
+		return
+	}
+
+	if p.exposeHeaders != "" {
+		header.Set(headerExposeHeaders, p.exposeHeaders)
+	}
+
+	ctx.Next()
+}
+
+// getPolicies compiles the policies of the options and of the http configurations, after the http service starts
+func (m *Middleware) getPolicies() ([]*policy, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.policies != nil {
+		return m.policies, nil
+	}
+
+	var config httpConfig.CorsConfig
+	if cfg := m.app.Http().Config(); cfg != nil {
+		config = cfg.Cors
+	}
+
+	if m.policy != nil {
+		config.Policy = *m.policy
+	}
+
+	p, err := newPolicy("", config.Policy)
+	if err != nil {
+		return nil, errorCodes.ErrorInvalidCorsConfig().Formats(err)
+	}
+	policies := []*policy{p}
+
+	for _, group := range append(config.Groups, m.groups...) {
+		if p, err = newPolicy(group.Path, group.Policy); err != nil {
+			return nil, errorCodes.ErrorInvalidCorsConfig().Formats(err)
+		}
+		policies = append(policies, p)
+	}
+
+	// the policy of the most specific group is found first
+	sort.SliceStable(policies, func(i, j int) bool {
+		return len(policies[i].path) > len(policies[j].path)
+	})
+
+	m.policies = policies
+	return m.policies, nil
+}
+
+// find finds the policy of a path
+func (m *Middleware) find(policies []*policy, path string) *policy {
+	for _, p := range policies {
+		if p.matches(path) {
+			return p
+		}
+	}
+	return nil
+}
+
+// abort aborts the request with an error
+func (m *Middleware) abort(ctx *gin.Context, status int, err error) {
+	if errorDetails, ok := err.(errors.ErrorDetails); ok {
+		err = errorDetails.SetStatusCode(status)
+	}
+	ctx.AbortWithStatusJSON(response.GetResponse(nil, nil, nil, err))
+}
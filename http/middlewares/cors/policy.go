@@ -0,0 +1,125 @@
+package cors
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+)
+
+// policy compiled cors policy
+type policy struct {
+	// Path of the group, empty for every route
+	path string
+	// All Origins allows every origin
+	allOrigins bool
+	// Origins allowed
+	origins map[string]bool
+	// Patterns of the origins allowed
+	patterns []*regexp.Regexp
+	// Allow Methods
+	allowMethods string
+	// Allow Headers
+	allowHeaders string
+	// Expose Headers
+	exposeHeaders string
+	// Allow Credentials
+	allowCredentials bool
+	// Max Age
+	maxAge string
+}
+
+// newPolicy compiles a cors policy
+func newPolicy(path string, config httpConfig.CorsPolicy) (*policy, error) {
+	p := &policy{
+		path:             strings.TrimSuffix(path, "/"),
+		origins:          make(map[string]bool),
+		allowMethods:     strings.ToUpper(strings.Join(config.AllowMethods, ", ")),
+		allowHeaders:     strings.Join(config.AllowHeaders, ", "),
+		exposeHeaders:    strings.Join(config.ExposeHeaders, ", "),
+		allowCredentials: config.AllowCredentials,
+	}
+
+	if config.MaxAgeSeconds > 0 {
+		p.maxAge = strconv.Itoa(config.MaxAgeSeconds)
+	}
+
+	for _, origin := range config.AllowOrigins {
+		switch {
+		case origin == AllOrigins:
+			p.allOrigins = true
+		case strings.HasPrefix(origin, RegexPrefix):
+			pattern, err := regexp.Compile(strings.TrimPrefix(origin, RegexPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("invalid origin %s: %w", origin, err)
+			}
+			p.patterns = append(p.patterns, pattern)
+		case strings.Contains(origin, "*"):
+			// the wildcards only match the characters of the hosts
+			pattern := strings.ReplaceAll(regexp.QuoteMeta(strings.ToLower(origin)), `\*`, `[a-z0-9.-]*`)
+			p.patterns = append(p.patterns, regexp.MustCompile("^"+pattern+"$"))
+		default:
+			p.origins[strings.ToLower(origin)] = true
+		}
+	}
+
+	return p, nil
+}
+
+// matches checks if the policy is of the group of a path
+func (p *policy) matches(path string) bool {
+	return path == p.path || strings.HasPrefix(path, p.path+"/")
+}
+
+// allowed checks if an origin is allowed
+func (p *policy) allowed(origin string) bool {
+	if p.allOrigins || p.origins[strings.ToLower(origin)] {
+		return true
+	}
+
+	for _, pattern := range p.patterns {
+		if pattern.MatchString(origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setHeaders sets the headers of the requests of an allowed origin
+func (p *policy) setHeaders(header http.Header, origin string) {
+	// the origin is echoed, since the credentials are not allowed with every origin
+	if p.allOrigins && !p.allowCredentials {
+		header.Set(headerAllowOrigin, AllOrigins)
+	} else {
+		header.Set(headerAllowOrigin, origin)
+		header.Add(headerVary, headerOrigin)
+	}
+
+	if p.allowCredentials {
+		header.Set(headerAllowCredentials, "true")
+	}
+}
+
+// setPreflightHeaders sets the headers of the preflight requests of an allowed origin
+func (p *policy) setPreflightHeaders(header http.Header, requestHeaders string) {
+	header.Add(headerVary, headerRequestMethod)
+	header.Add(headerVary, headerRequestHeaders)
+
+	if p.allowMethods != "" {
+		header.Set(headerAllowMethods, p.allowMethods)
+	}
+
+	if p.allowHeaders != "" {
+		header.Set(headerAllowHeaders, p.allowHeaders)
+	} else if requestHeaders != "" {
+		header.Set(headerAllowHeaders, requestHeaders)
+	}
+
+	if p.maxAge != "" {
+		header.Set(headerMaxAge, p.maxAge)
+	}
+}
@@ -0,0 +1,119 @@
+package jwt
+
+import (
+	"encoding/json"
+	"time"
+
+	contextDomain "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/context"
+)
+
+// IClaims claims of a token.
+// Custom claims embed the Claims struct, for example:
+//
+//	type UserClaims struct {
+//		jwt.Claims
+//		Role string `json:"role"`
+//	}
+type IClaims interface {
+	// Registered gets the registered claims
+	Registered() *Claims
+}
+
+// Claims registered claims of a token
+type Claims struct {
+	// Issuer
+	Issuer string `json:"iss,omitempty"`
+	// Subject
+	Subject string `json:"sub,omitempty"`
+	// Audience
+	Audience Audience `json:"aud,omitempty"`
+	// Expires At (unix time)
+	ExpiresAt int64 `json:"exp,omitempty"`
+	// Not Before (unix time)
+	NotBefore int64 `json:"nbf,omitempty"`
+	// Issued At (unix time)
+	IssuedAt int64 `json:"iat,omitempty"`
+	// Id
+	Id string `json:"jti,omitempty"`
+	// Original Issued At (unix time) of the first token, kept by the refreshes
+	OriginalIssuedAt int64 `json:"orig_iat,omitempty"`
+}
+
+// Registered gets the registered claims
+func (c *Claims) Registered() *Claims {
+	return c
+}
+
+// valid checks the expiration and the not before times, the tokens without expiration are not valid
+func (c *Claims) valid(now time.Time) error {
+	if c.ExpiresAt <= 0 {
+		return errMissingExpiration
+	}
+
+	if now.Unix() >= c.ExpiresAt {
+		return errTokenExpired
+	}
+
+	if c.NotBefore > 0 && now.Unix() < c.NotBefore {
+		return errTokenNotValidYet
+	}
+
+	return nil
+}
+
+// Audience audience of a token, a single value or a list of values
+type Audience []string
+
+// Contains checks if the audience contains a value
+func (a Audience) Contains(value string) bool {
+	for _, audience := range a {
+		if audience == value {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON encodes a single value as a string and multiple values as a list
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON decodes a string or a list of strings
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*a = nil
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*a = Audience{value}
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*a = values
+	return nil
+}
+
+// GetClaims gets the claims of the authenticated token from the context
+func GetClaims[T IClaims](ctx contextDomain.IContext) (claims T, ok bool) {
+	if ctx == nil {
+		return claims, false
+	}
+
+	value, exists := ctx.Get(ClaimsKey)
+	if !exists {
+		return claims, false
+	}
+
+	claims, ok = value.(T)
+	return claims, ok
+}
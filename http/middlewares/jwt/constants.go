@@ -0,0 +1,47 @@
+package jwt
+
+import (
+	"crypto"
+	"time"
+)
+
+// Algorithms
+const (
+	// AlgorithmHS256 HMAC with SHA-256
+	AlgorithmHS256 = "HS256"
+	// AlgorithmHS384 HMAC with SHA-384
+	AlgorithmHS384 = "HS384"
+	// AlgorithmHS512 HMAC with SHA-512
+	AlgorithmHS512 = "HS512"
+	// AlgorithmRS256 RSASSA-PKCS1-v1_5 with SHA-256
+	AlgorithmRS256 = "RS256"
+	// AlgorithmRS384 RSASSA-PKCS1-v1_5 with SHA-384
+	AlgorithmRS384 = "RS384"
+	// AlgorithmRS512 RSASSA-PKCS1-v1_5 with SHA-512
+	AlgorithmRS512 = "RS512"
+)
+
+const (
+	// ClaimsKey context key of the claims of the authenticated token
+	ClaimsKey = "jwt.claims"
+	// authorizationHeader header with the token
+	authorizationHeader = "Authorization"
+	// bearerPrefix prefix of the token in the authorization header
+	bearerPrefix = "Bearer "
+	// tokenType type of the tokens
+	tokenType = "JWT"
+	// defaultExpiry expiry of the signed tokens when it is not configured
+	defaultExpiry = 24 * time.Hour
+	// defaultMaxRefresh max refresh since the first issue of the tokens when it is not configured
+	defaultMaxRefresh = 7 * 24 * time.Hour
+)
+
+// algorithms hashes of the algorithms
+var algorithms = map[string]crypto.Hash{
+	AlgorithmHS256: crypto.SHA256,
+	AlgorithmHS384: crypto.SHA384,
+	AlgorithmHS512: crypto.SHA512,
+	AlgorithmRS256: crypto.SHA256,
+	AlgorithmRS384: crypto.SHA384,
+	AlgorithmRS512: crypto.SHA512,
+}
@@ -0,0 +1,120 @@
+package jwt
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/errors"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/response"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+)
+
+// Middleware validates the bearer tokens of the requests and sets their claims in the context
+type Middleware struct {
+	// App
+	app domain.IApp
+	// Manager
+	manager *Manager
+	// New Claims creates the claims of a token
+	newClaims func() IClaims
+	// Mutex
+	mux sync.Mutex
+}
+
+// Option option of the middleware
+type Option func(m *Middleware)
+
+// WithClaims sets the function that creates the typed claims of the tokens
+func WithClaims(newClaims func() IClaims) Option {
+	return func(m *Middleware) {
+		m.newClaims = newClaims
+	}
+}
+
+// WithManager sets the manager, instead of creating it with the http configurations
+func WithManager(manager *Manager) Option {
+	return func(m *Middleware) {
+		m.manager = manager
+	}
+}
+
+// NewMiddleware creates a new jwt middleware
+func NewMiddleware(app domain.IApp, opts ...Option) *Middleware {
+	m := &Middleware{
+		app:       app,
+		newClaims: func() IClaims { return &Claims{} },
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// RegisterMiddlewares registers the middleware in every route
+func (m *Middleware) RegisterMiddlewares() {
+	m.app.Http().Router().Use(m.GetHandlers()...)
+}
+
+// GetHandlers gets the handlers of the middleware
+func (m *Middleware) GetHandlers() []gin.HandlerFunc {
+	return []gin.HandlerFunc{m.handle}
+}
+
+// Manager gets the manager, that is created with the http configurations after the http service starts
+func (m *Middleware) Manager() (*Manager, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.manager == nil {
+		manager, err := NewManager(m.app.Http().Config())
+		if err != nil {
+			return nil, err
+		}
+		m.manager = manager
+	}
+
+	return m.manager, nil
+}
+
+// handle authenticates a request
+func (m *Middleware) handle(ctx *gin.Context) {
+	manager, err := m.Manager()
+	if err != nil {
+		m.abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	authorization := ctx.GetHeader(authorizationHeader)
+	if authorization == "" {
+		m.abort(ctx, http.StatusUnauthorized, errorCodes.ErrorAuthorizationMissing())
+		return
+	}
+
+	if len(authorization) < len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		m.abort(ctx, http.StatusUnauthorized, errorCodes.ErrorInvalidBearerKey())
+		return
+	}
+
+	claims := m.newClaims()
+	if err = manager.Parse(strings.TrimSpace(authorization[len(bearerPrefix):]), claims); err != nil {
+		m.abort(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	ctx.Set(ClaimsKey, claims)
+	ctx.Next()
+}
+
+// abort aborts the request with an error
+func (m *Middleware) abort(ctx *gin.Context, status int, err error) {
+	if errorDetails, ok := err.(errors.ErrorDetails); ok {
+		err = errorDetails.SetStatusCode(status)
+	}
+	ctx.AbortWithStatusJSON(response.GetResponse(nil, nil, nil, err))
+}
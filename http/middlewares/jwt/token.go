@@ -0,0 +1,363 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers the SHA-256 hash
+	_ "crypto/sha512" // registers the SHA-384 and SHA-512 hashes
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+)
+
+var (
+	errTokenExpired      = errors.New("token expired")
+	errTokenNotValidYet  = errors.New("token not valid yet")
+	errMissingExpiration = errors.New("missing expiration")
+	errInvalidSignature  = errors.New("invalid signature")
+	errMalformedToken    = errors.New("malformed token")
+	errInvalidIssuer     = errors.New("invalid issuer")
+	errInvalidAudience   = errors.New("invalid audience")
+)
+
+// header header of a token
+type header struct {
+	// Algorithm
+	Algorithm string `json:"alg"`
+	// Type
+	Type string `json:"typ"`
+}
+
+// Manager signs, validates and refreshes tokens
+type Manager struct {
+	// Algorithm
+	algorithm string
+	// Hash of the algorithm
+	hash crypto.Hash
+	// Secret of the HS algorithms
+	secret []byte
+	// Public Key of the RS algorithms
+	publicKey *rsa.PublicKey
+	// Private Key of the RS algorithms
+	privateKey *rsa.PrivateKey
+	// Expiry of the signed tokens
+	expiry time.Duration
+	// Issuer of the signed and of the accepted tokens
+	issuer string
+	// Audience of the signed and of the accepted tokens
+	audience string
+	// Max Refresh since the first issue of the tokens, without limit when negative
+	maxRefresh time.Duration
+	// Now gets the current time
+	now func() time.Time
+}
+
+// NewManager creates a new manager with the jwt configurations of the http service
+func NewManager(config *httpConfig.Config) (*Manager, error) {
+	if config == nil {
+		return nil, errorCodes.ErrorInvalidJwtKey().Formats("missing http configurations")
+	}
+
+	m := &Manager{
+		algorithm:  config.JwtAlgorithm,
+		expiry:     time.Duration(config.JwtExpiryTimeHours) * time.Hour,
+		issuer:     config.JwtIssuer,
+		audience:   config.JwtAudience,
+		maxRefresh: time.Duration(config.JwtMaxRefreshHours) * time.Hour,
+		now:        time.Now,
+	}
+
+	if m.algorithm == "" {
+		m.algorithm = AlgorithmHS256
+	}
+
+	if m.expiry <= 0 {
+		m.expiry = defaultExpiry
+	}
+
+	if m.maxRefresh == 0 {
+		m.maxRefresh = defaultMaxRefresh
+	}
+
+	var ok bool
+	if m.hash, ok = algorithms[m.algorithm]; !ok {
+		return nil, errorCodes.ErrorUnexpectedSigninMethod().Formats(m.algorithm)
+	}
+
+	if m.isHmac() {
+		if config.JwtSecret == "" {
+			return nil, errorCodes.ErrorInvalidJwtKey().Formats("missing secret")
+		}
+		m.secret = []byte(config.JwtSecret)
+		return m, nil
+	}
+
+	if config.JwtPrivateKey != "" {
+		privateKey, err := parsePrivateKey(config.JwtPrivateKey)
+		if err != nil {
+			return nil, errorCodes.ErrorInvalidJwtKey().Formats(err)
+		}
+		m.privateKey = privateKey
+		m.publicKey = &privateKey.PublicKey
+	}
+
+	if config.JwtPublicKey != "" {
+		publicKey, err := parsePublicKey(config.JwtPublicKey)
+		if err != nil {
+			return nil, errorCodes.ErrorInvalidJwtKey().Formats(err)
+		}
+		m.publicKey = publicKey
+	}
+
+	if m.publicKey == nil {
+		return nil, errorCodes.ErrorInvalidJwtKey().Formats("missing public key")
+	}
+
+	return m, nil
+}
+
+// Sign signs the claims, setting the issuer, the audience, the issue and the expiration times when missing
+func (m *Manager) Sign(claims IClaims) (string, error) {
+	if claims == nil || claims.Registered() == nil {
+		return "", errorCodes.ErrorNoClaimsFound()
+	}
+
+	if !m.isHmac() && m.privateKey == nil {
+		return "", errorCodes.ErrorInvalidJwtKey().Formats("missing private key")
+	}
+
+	now := m.now()
+	registered := claims.Registered()
+	if registered.IssuedAt == 0 {
+		registered.IssuedAt = now.Unix()
+	}
+	if registered.ExpiresAt == 0 {
+		registered.ExpiresAt = now.Add(m.expiry).Unix()
+	}
+	if registered.OriginalIssuedAt == 0 {
+		registered.OriginalIssuedAt = registered.IssuedAt
+	}
+	if registered.Issuer == "" {
+		registered.Issuer = m.issuer
+	}
+	if len(registered.Audience) == 0 && m.audience != "" {
+		registered.Audience = Audience{m.audience}
+	}
+
+	headerJson, err := json.Marshal(header{Algorithm: m.algorithm, Type: tokenType})
+	if err != nil {
+		return "", err
+	}
+
+	claimsJson, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := encode(headerJson) + "." + encode(claimsJson)
+	signature, err := m.sign(unsigned)
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + encode(signature), nil
+}
+
+// Parse validates a token and decodes its claims, with the issuer and the audience when they are configured
+func (m *Manager) Parse(token string, claims IClaims) error {
+	if token == "" {
+		return errorCodes.ErrorTokenStringEmpty()
+	}
+
+	if claims == nil || claims.Registered() == nil {
+		return errorCodes.ErrorNoClaimsFound()
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errorCodes.ErrorInvalidToken().Formats(errMalformedToken)
+	}
+
+	headerJson, err := decode(parts[0])
+	if err != nil {
+		return errorCodes.ErrorInvalidToken().Formats(errMalformedToken)
+	}
+
+	var h header
+	if err = json.Unmarshal(headerJson, &h); err != nil {
+		return errorCodes.ErrorInvalidToken().Formats(errMalformedToken)
+	}
+
+	// the algorithm is never chosen by the token, to prevent downgrades
+	if h.Algorithm != m.algorithm {
+		return errorCodes.ErrorUnexpectedSigninMethod().Formats(h.Algorithm)
+	}
+
+	signature, err := decode(parts[2])
+	if err != nil {
+		return errorCodes.ErrorInvalidToken().Formats(errMalformedToken)
+	}
+
+	if err = m.verify(parts[0]+"."+parts[1], signature); err != nil {
+		return errorCodes.ErrorInvalidToken().Formats(err)
+	}
+
+	claimsJson, err := decode(parts[1])
+	if err != nil {
+		return errorCodes.ErrorInvalidToken().Formats(errMalformedToken)
+	}
+
+	if err = json.Unmarshal(claimsJson, claims); err != nil {
+		return errorCodes.ErrorInvalidToken().Formats(errMalformedToken)
+	}
+
+	if err = claims.Registered().valid(m.now()); err != nil {
+		if errors.Is(err, errTokenExpired) {
+			return errorCodes.ErrorTokenExpired()
+		}
+		return errorCodes.ErrorInvalidToken().Formats(err)
+	}
+
+	registered := claims.Registered()
+	if m.issuer != "" && registered.Issuer != m.issuer {
+		return errorCodes.ErrorInvalidToken().Formats(errInvalidIssuer)
+	}
+
+	if m.audience != "" && !registered.Audience.Contains(m.audience) {
+		return errorCodes.ErrorInvalidToken().Formats(errInvalidAudience)
+	}
+
+	return nil
+}
+
+// Refresh validates a token and signs its claims again with new issue and expiration times,
+// until the max refresh since the first issue of the token
+func (m *Manager) Refresh(token string, claims IClaims) (string, error) {
+	if err := m.Parse(token, claims); err != nil {
+		return "", err
+	}
+
+	registered := claims.Registered()
+	issuedAt := registered.OriginalIssuedAt
+	if issuedAt == 0 {
+		issuedAt = registered.IssuedAt
+	}
+
+	// the tokens without issue time can not be refreshed when the refresh is limited
+	if m.maxRefresh > 0 && (issuedAt == 0 || !m.now().Before(time.Unix(issuedAt, 0).Add(m.maxRefresh))) {
+		return "", errorCodes.ErrorTokenExpired()
+	}
+
+	registered.OriginalIssuedAt = issuedAt
+	registered.IssuedAt = 0
+	registered.ExpiresAt = 0
+
+	return m.Sign(claims)
+}
+
+// isHmac checks if the algorithm is an HS algorithm
+func (m *Manager) isHmac() bool {
+	return strings.HasPrefix(m.algorithm, "HS")
+}
+
+// sign signs a text
+func (m *Manager) sign(text string) ([]byte, error) {
+	if m.isHmac() {
+		mac := hmac.New(m.hash.New, m.secret)
+		mac.Write([]byte(text))
+		return mac.Sum(nil), nil
+	}
+
+	hasher := m.hash.New()
+	hasher.Write([]byte(text))
+	return rsa.SignPKCS1v15(rand.Reader, m.privateKey, m.hash, hasher.Sum(nil))
+}
+
+// verify verifies the signature of a text
+func (m *Manager) verify(text string, signature []byte) error {
+	if m.isHmac() {
+		expected, _ := m.sign(text)
+		if !hmac.Equal(signature, expected) {
+			return errInvalidSignature
+		}
+		return nil
+	}
+
+	hasher := m.hash.New()
+	hasher.Write([]byte(text))
+	if err := rsa.VerifyPKCS1v15(m.publicKey, m.hash, hasher.Sum(nil), signature); err != nil {
+		return errInvalidSignature
+	}
+
+	return nil
+}
+
+// encode encodes to base64 url without padding
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decode decodes from base64 url without padding
+func decode(text string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(text)
+}
+
+// parsePrivateKey parses a PKCS1 or PKCS8 rsa private key
+func parsePrivateKey(key string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("invalid private key PEM")
+	}
+
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an rsa key")
+	}
+
+	return privateKey, nil
+}
+
+// parsePublicKey parses a PKIX or PKCS1 rsa public key, or the public key of a certificate
+func parsePublicKey(key string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("invalid public key PEM")
+	}
+
+	if publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return publicKey, nil
+	}
+
+	var parsed any
+	if certificate, err := x509.ParseCertificate(block.Bytes); err == nil {
+		parsed = certificate.PublicKey
+	} else if parsed, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is not an rsa key")
+	}
+
+	return publicKey, nil
+}
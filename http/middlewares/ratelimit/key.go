@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"github.com/gin-gonic/gin"
+
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/middlewares/jwt"
+)
+
+// KeyFunc gets a part of the key of a request
+type KeyFunc func(ctx *gin.Context) string
+
+// ByIp gets the client ip
+func ByIp(ctx *gin.Context) string {
+	return "ip:" + ctx.ClientIP()
+}
+
+// ByRoute gets the route, that limits the requests of every client of the route together
+func ByRoute(ctx *gin.Context) string {
+	route := ctx.FullPath()
+	if route == "" {
+		route = ctx.Request.URL.Path
+	}
+	return "route:" + ctx.Request.Method + " " + route
+}
+
+// ByApiKey gets the name of the api key, set by the api key middleware, or the client ip without it
+func ByApiKey(ctx *gin.Context) string {
+	if name := ctx.GetString(contextInfra.CtxApiKeyName); name != "" {
+		return "apiKey:" + name
+	}
+	return ByIp(ctx)
+}
+
+// ByJwtSubject gets the subject of the token, set by the jwt middleware, or the client ip without it
+func ByJwtSubject(ctx *gin.Context) string {
+	if value, exists := ctx.Get(jwt.ClaimsKey); exists {
+		if claims, ok := value.(jwt.IClaims); ok && claims.Registered() != nil && claims.Registered().Subject != "" {
+			return "sub:" + claims.Registered().Subject
+		}
+	}
+	return ByIp(ctx)
+}
@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/errors"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/response"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/ratelimit"
+)
+
+const (
+	headerLimit      = "RateLimit-Limit"
+	headerRemaining  = "RateLimit-Remaining"
+	headerReset      = "RateLimit-Reset"
+	headerRetryAfter = "Retry-After"
+
+	// storeMemory store that keeps the limits in the memory of the instance
+	storeMemory = "memory"
+)
+
+// Middleware limits the requests of the keys, for example of a group or an endpoint
+type Middleware struct {
+	// App
+	app domain.IApp
+	// Rule
+	rule ratelimit.Rule
+	// Keys get the parts of the key of a request
+	keys []KeyFunc
+	// Limiter
+	limiter *ratelimit.Limiter
+	// Mutex
+	mux sync.Mutex
+}
+
+// Option option of the middleware
+type Option func(m *Middleware)
+
+// WithKey sets the parts of the key of the requests, for example WithKey(ByRoute, ByIp)
+func WithKey(keys ...KeyFunc) Option {
+	return func(m *Middleware) {
+		m.keys = keys
+	}
+}
+
+// WithLimiter sets the limiter, instead of creating it with the http configurations
+func WithLimiter(limiter *ratelimit.Limiter) Option {
+	return func(m *Middleware) {
+		m.limiter = limiter
+	}
+}
+
+// NewMiddleware creates a new rate limit middleware, that limits the requests by ip by default
+func NewMiddleware(app domain.IApp, rule ratelimit.Rule, opts ...Option) *Middleware {
+	m := &Middleware{
+		app:  app,
+		rule: rule,
+		keys: []KeyFunc{ByIp},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// RegisterMiddlewares registers the middleware in every route
+func (m *Middleware) RegisterMiddlewares() {
+	m.app.Http().Router().Use(m.GetHandlers()...)
+}
+
+// GetHandlers gets the handlers of the middleware
+func (m *Middleware) GetHandlers() []gin.HandlerFunc {
+	return []gin.HandlerFunc{m.handle}
+}
+
+// Limiter gets the limiter, that is created with the http configurations after the http service starts
+func (m *Middleware) Limiter() *ratelimit.Limiter {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.limiter == nil {
+		cfg := m.app.Http().Config()
+		if m.app.Redis() == nil || cfg == nil || cfg.RateLimit.Store == storeMemory {
+			m.limiter = ratelimit.NewLimiter(ratelimit.NewMemoryStore())
+		} else {
+			m.limiter = ratelimit.NewLimiter(ratelimit.NewRedisStore(m.app.Redis(), cfg.RateLimit.Prefix))
+		}
+	}
+
+	return m.limiter
+}
+
+// handle limits a request
+func (m *Middleware) handle(ctx *gin.Context) {
+	result, err := m.Limiter().Allow(ctx.Request.Context(), m.key(ctx), m.rule)
+	if err != nil {
+		// the requests are not blocked when the limits are unavailable
+		_ = ctx.Error(err)
+		ctx.Next()
+		return
+	}
+
+	header := ctx.Writer.Header()
+	header.Set(headerLimit, strconv.Itoa(result.Limit))
+	header.Set(headerRemaining, strconv.Itoa(result.Remaining))
+	header.Set(headerReset, seconds(result.Reset))
+
+	if !result.Allowed {
+		header.Set(headerRetryAfter, seconds(result.RetryAfter))
+		m.abort(ctx, http.StatusTooManyRequests,
+			errorCodes.ErrorRateLimitExceeded().Formats(seconds(result.RetryAfter)+"s"))
+		return
+	}
+
+	ctx.Next()
+}
+
+// key gets the key of a request
+func (m *Middleware) key(ctx *gin.Context) string {
+	key := ""
+	for i, keyFunc := range m.keys {
+		if i > 0 {
+			key += ":"
+		}
+		key += keyFunc(ctx)
+	}
+	return key
+}
+
+// abort aborts the request with an error
+func (m *Middleware) abort(ctx *gin.Context, status int, err error) {
+	if errorDetails, ok := err.(errors.ErrorDetails); ok {
+		err = errorDetails.SetStatusCode(status)
+	}
+	ctx.AbortWithStatusJSON(response.GetResponse(nil, nil, nil, err))
+}
+
+// seconds gets the seconds of a duration, rounded up
+func seconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
@@ -0,0 +1,98 @@
+package session
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+
+	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+)
+
+var (
+	errInvalidSignature = errors.New("invalid signature")
+	errInvalidCookie    = errors.New("invalid cookie")
+)
+
+// codec signs and, optionally, encrypts the cookies
+type codec struct {
+	// Secret to sign the cookies
+	secret []byte
+	// AEAD to encrypt the cookies, nil when the encryption is disabled
+	aead cipher.AEAD
+}
+
+// newCodec creates a new codec with the session configurations
+func newCodec(config httpConfig.SessionConfig) (*codec, error) {
+	if config.Secret == "" {
+		return nil, errors.New("missing secret")
+	}
+
+	c := &codec{
+		secret: []byte(config.Secret),
+	}
+
+	if config.EncryptionKey != "" {
+		// the key is derived to have the size of an AES-256 key
+		key := sha256.Sum256([]byte(config.EncryptionKey))
+		block, err := aes.NewCipher(key[:])
+		if err != nil {
+			return nil, err
+		}
+		if c.aead, err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// encode encodes the value of a cookie, getting the cookie and its signature
+func (c *codec) encode(name string, value string) (cookie string, signature string, err error) {
+	data := []byte(value)
+	if c.aead != nil {
+		nonce := make([]byte, c.aead.NonceSize())
+		if _, err = rand.Read(nonce); err != nil {
+			return "", "", err
+		}
+		data = c.aead.Seal(nonce, nonce, data, []byte(name))
+	}
+
+	cookie = base64.RawURLEncoding.EncodeToString(data)
+	return cookie, base64.RawURLEncoding.EncodeToString(c.sign(name, cookie)), nil
+}
+
+// decode validates the signature of a cookie and decodes its value
+func (c *codec) decode(name string, cookie string, signature string) (string, error) {
+	decodedSignature, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(decodedSignature, c.sign(name, cookie)) {
+		return "", errInvalidSignature
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cookie)
+	if err != nil {
+		return "", errInvalidCookie
+	}
+
+	if c.aead != nil {
+		if len(data) < c.aead.NonceSize() {
+			return "", errInvalidCookie
+		}
+		nonce, encrypted := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+		if data, err = c.aead.Open(nil, nonce, encrypted, []byte(name)); err != nil {
+			return "", errInvalidCookie
+		}
+	}
+
+	return string(data), nil
+}
+
+// sign signs a cookie with its name, so that a signature is not valid for other cookies
+func (c *codec) sign(name string, cookie string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(name + "=" + cookie))
+	return mac.Sum(nil)
+}
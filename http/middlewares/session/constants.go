@@ -0,0 +1,19 @@
+package session
+
+import "time"
+
+const (
+	// StoreCookie store that keeps the values of the sessions in the cookies
+	StoreCookie = "cookie"
+	// StoreRedis store that keeps the values of the sessions in redis
+	StoreRedis = "redis"
+
+	// signatureSuffix suffix of the name of the signature cookie
+	signatureSuffix = ".sig"
+	// maxCookieSize maximum size of a cookie accepted by the browsers
+	maxCookieSize = 4096
+	// idSize size of the random ids
+	idSize = 32
+	// defaultInactivity inactivity after which the sessions expire when it is not configured
+	defaultInactivity = 30 * time.Minute
+)
@@ -0,0 +1,280 @@
+package session
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/errors"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/response"
+
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+)
+
+// Middleware loads the sessions of the requests from signed cookies and saves them before the responses are written.
+// The sessions expire after the inactivity time, that is renewed in every request
+type Middleware struct {
+	// App
+	app domain.IApp
+	// Store
+	store IStore
+	// Codec
+	codec *codec
+	// Required rejects the requests without a valid session
+	required bool
+	// Now gets the current time
+	now func() time.Time
+	// Mutex
+	mux sync.Mutex
+}
+
+// Option option of the middleware
+type Option func(m *Middleware)
+
+// WithStore sets the store, instead of creating it with the http configurations
+func WithStore(store IStore) Option {
+	return func(m *Middleware) {
+		m.store = store
+	}
+}
+
+// WithRequired rejects the requests without a valid session, instead of creating a new session
+func WithRequired() Option {
+	return func(m *Middleware) {
+		m.required = true
+	}
+}
+
+// NewMiddleware creates a new session middleware
+func NewMiddleware(app domain.IApp, opts ...Option) *Middleware {
+	m := &Middleware{
+		app: app,
+		now: time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// RegisterMiddlewares registers the middleware in every route
+func (m *Middleware) RegisterMiddlewares() {
+	m.app.Http().Router().Use(m.GetHandlers()...)
+}
+
+// GetHandlers gets the handlers of the middleware
+func (m *Middleware) GetHandlers() []gin.HandlerFunc {
+	return []gin.HandlerFunc{m.handle}
+}
+
+// handle loads the session of a request
+func (m *Middleware) handle(ctx *gin.Context) {
+	config, err := m.config()
+	if err != nil {
+		m.abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	store, codec, err := m.init(config)
+	if err != nil {
+		m.abort(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	session, err := m.load(ctx, config, store, codec)
+	if err != nil {
+		if m.required {
+			m.expire(ctx, config)
+			m.abort(ctx, http.StatusUnauthorized, err)
+			return
+		}
+		session = newSession()
+	}
+
+	ctx.Set(contextInfra.CtxSession, session)
+
+	// the cookies are set before the headers are written
+	w := &writer{ResponseWriter: ctx.Writer}
+	w.commit = func() {
+		if err := m.save(ctx, config, store, codec, session); err != nil {
+			_ = ctx.Error(err)
+		}
+	}
+	ctx.Writer = w
+
+	ctx.Next()
+
+	w.commitOnce()
+}
+
+// config gets the http configurations
+func (m *Middleware) config() (*httpConfig.Config, error) {
+	config := m.app.Http().Config()
+	if config == nil {
+		return nil, errorCodes.ErrorInvalidSessionConfig().Formats("missing http configurations")
+	}
+	return config, nil
+}
+
+// init creates the store and the codec with the http configurations, after the http service starts
+func (m *Middleware) init(config *httpConfig.Config) (IStore, *codec, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.codec == nil {
+		c, err := newCodec(config.Session)
+		if err != nil {
+			return nil, nil, errorCodes.ErrorInvalidSessionConfig().Formats(err)
+		}
+		m.codec = c
+	}
+
+	if m.store == nil {
+		switch config.Session.Store {
+		case StoreRedis:
+			if m.app.Redis() == nil {
+				return nil, nil, errorCodes.ErrorInvalidSessionConfig().Formats("missing redis service")
+			}
+			m.store = NewRedisStore(m.app.Redis(), config.Session.RedisPrefix)
+		default:
+			m.store = NewCookieStore()
+		}
+	}
+
+	return m.store, m.codec, nil
+}
+
+// load loads the session of the cookies
+func (m *Middleware) load(ctx *gin.Context, config *httpConfig.Config, store IStore, codec *codec) (*Session, error) {
+	name := config.Session.CookieName
+
+	cookie, err := ctx.Cookie(name)
+	if err != nil || cookie == "" {
+		return nil, errorCodes.ErrorMissingSignatureCookie()
+	}
+
+	signature, err := ctx.Cookie(name + signatureSuffix)
+	if err != nil || signature == "" {
+		return nil, errorCodes.ErrorMissingSignatureCookie()
+	}
+
+	value, err := codec.decode(name, cookie, signature)
+	if err != nil {
+		return nil, errorCodes.ErrorInvalidSignatureCookie()
+	}
+
+	data, err := store.Load(ctx.Request.Context(), value)
+	if err != nil {
+		_ = ctx.Error(err)
+		return nil, errorCodes.ErrorInvalidSignatureCookie()
+	}
+
+	if data == nil {
+		return nil, errorCodes.ErrorSessionExpired()
+	}
+
+	if m.now().Sub(time.Unix(data.LastAccess, 0)) > inactivity(config) {
+		if err = store.Delete(ctx.Request.Context(), data.Id); err != nil {
+			_ = ctx.Error(err)
+		}
+		return nil, errorCodes.ErrorSessionExpired()
+	}
+
+	return loadSession(data), nil
+}
+
+// save saves the session and sets its cookies, renewing the inactivity time
+func (m *Middleware) save(ctx *gin.Context, config *httpConfig.Config, store IStore, codec *codec, session *Session) error {
+	session.mux.Lock()
+	defer session.mux.Unlock()
+
+	if session.previousId != "" {
+		if err := store.Delete(ctx.Request.Context(), session.previousId); err != nil {
+			return err
+		}
+	}
+
+	if session.destroyed {
+		if !session.isNew {
+			if err := store.Delete(ctx.Request.Context(), session.data.Id); err != nil {
+				return err
+			}
+		}
+		m.expire(ctx, config)
+		return nil
+	}
+
+	// the new sessions without values are not saved
+	if session.isNew && !session.modified {
+		return nil
+	}
+
+	expiration := inactivity(config)
+	session.data.LastAccess = m.now().Unix()
+
+	value, err := store.Save(ctx.Request.Context(), session.data, expiration)
+	if err != nil {
+		return err
+	}
+
+	cookie, signature, err := codec.encode(config.Session.CookieName, value)
+	if err != nil {
+		return err
+	}
+
+	if len(cookie) > maxCookieSize {
+		return fmt.Errorf("session cookie with %d bytes exceeds the maximum of %d bytes", len(cookie), maxCookieSize)
+	}
+
+	m.setCookie(ctx, config, config.Session.CookieName, cookie, int(expiration.Seconds()))
+	m.setCookie(ctx, config, config.Session.CookieName+signatureSuffix, signature, int(expiration.Seconds()))
+
+	return nil
+}
+
+// expire expires the cookies of the session
+func (m *Middleware) expire(ctx *gin.Context, config *httpConfig.Config) {
+	m.setCookie(ctx, config, config.Session.CookieName, "", -1)
+	m.setCookie(ctx, config, config.Session.CookieName+signatureSuffix, "", -1)
+}
+
+// setCookie sets a cookie of the session
+func (m *Middleware) setCookie(ctx *gin.Context, config *httpConfig.Config, name string, value string, maxAge int) {
+	ctx.SetSameSite(sameSite(config.Session.SameSite))
+	ctx.SetCookie(name, value, maxAge, config.Session.Path, config.Session.Domain, config.Session.Secure, true)
+}
+
+// abort aborts the request with an error
+func (m *Middleware) abort(ctx *gin.Context, status int, err error) {
+	if errorDetails, ok := err.(errors.ErrorDetails); ok {
+		err = errorDetails.SetStatusCode(status)
+	}
+	ctx.AbortWithStatusJSON(response.GetResponse(nil, nil, nil, err))
+}
+
+// inactivity gets the inactivity time after which the sessions expire
+func inactivity(config *httpConfig.Config) time.Duration {
+	if config.CookieInactivityMinutes <= 0 {
+		return defaultInactivity
+	}
+	return time.Duration(config.CookieInactivityMinutes) * time.Minute
+}
+
+// sameSite gets the same site mode of the cookies
+func sameSite(mode string) http.SameSite {
+	switch mode {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
@@ -0,0 +1,150 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sync"
+)
+
+// Data stored data of a session.
+// The values are stored as json, so the numbers are read back as float64
+type Data struct {
+	// Id
+	Id string `json:"id"`
+	// Values
+	Values map[string]any `json:"values,omitempty"`
+	// Last Access (unix time), to expire the session after the inactivity time
+	LastAccess int64 `json:"lastAccess"`
+}
+
+// Session session of a request
+type Session struct {
+	// Data
+	data *Data
+	// Is New
+	isNew bool
+	// Modified
+	modified bool
+	// Destroyed
+	destroyed bool
+	// Previous Id, deleted from the store after the id is regenerated
+	previousId string
+	// Mutex
+	mux sync.RWMutex
+}
+
+// newSession creates a new session
+func newSession() *Session {
+	return &Session{
+		data: &Data{
+			Id:     newId(),
+			Values: make(map[string]any),
+		},
+		isNew: true,
+	}
+}
+
+// loadSession creates a session with stored data
+func loadSession(data *Data) *Session {
+	if data.Values == nil {
+		data.Values = make(map[string]any)
+	}
+
+	return &Session{
+		data: data,
+	}
+}
+
+// Id gets the id
+func (s *Session) Id() string {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return s.data.Id
+}
+
+// IsNew checks if the session was created in the request
+func (s *Session) IsNew() bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return s.isNew
+}
+
+// Get gets a value
+func (s *Session) Get(key string) (value any, exists bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	value, exists = s.data.Values[key]
+	return value, exists
+}
+
+// GetString gets a string value
+func (s *Session) GetString(key string) string {
+	value, exists := s.Get(key)
+	if !exists || value == nil {
+		return ""
+	}
+
+	if text, ok := value.(string); ok {
+		return text
+	}
+
+	return fmt.Sprint(value)
+}
+
+// Set sets a value
+func (s *Session) Set(key string, value any) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.data.Values[key] = value
+	s.modified = true
+}
+
+// Delete deletes a value
+func (s *Session) Delete(key string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.data.Values, key)
+	s.modified = true
+}
+
+// Clear deletes every value
+func (s *Session) Clear() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.data.Values = make(map[string]any)
+	s.modified = true
+}
+
+// Regenerate changes the id, keeping the values (for example after a login)
+func (s *Session) Regenerate() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if !s.isNew && s.previousId == "" {
+		s.previousId = s.data.Id
+	}
+	s.data.Id = newId()
+	s.modified = true
+}
+
+// Destroy deletes the session and expires its cookies
+func (s *Session) Destroy() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.destroyed = true
+}
+
+// newId creates a random id
+func newId() string {
+	id := make([]byte, idSize)
+	_, _ = rand.Read(id)
+	return base64.RawURLEncoding.EncodeToString(id)
+}
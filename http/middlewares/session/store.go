@@ -0,0 +1,104 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+)
+
+// IStore store of the sessions
+type IStore interface {
+	// Load loads a session with the value of its cookie, returning nil when the session does not exist
+	Load(ctx context.Context, value string) (*Data, error)
+	// Save saves a session until it expires and gets the value of its cookie
+	Save(ctx context.Context, data *Data, expiration time.Duration) (value string, err error)
+	// Delete deletes a session
+	Delete(ctx context.Context, id string) error
+}
+
+// CookieStore stateless store that keeps the values of the sessions in the cookies
+type CookieStore struct{}
+
+// NewCookieStore creates a new cookie store
+func NewCookieStore() *CookieStore {
+	return &CookieStore{}
+}
+
+// Load loads a session with the value of its cookie
+func (s *CookieStore) Load(_ context.Context, value string) (*Data, error) {
+	data := &Data{}
+	if err := json.Unmarshal([]byte(value), data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Save gets the value of the cookie of a session
+func (s *CookieStore) Save(_ context.Context, data *Data, _ time.Duration) (string, error) {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// Delete does nothing, since the session is deleted when its cookies expire
+func (s *CookieStore) Delete(_ context.Context, _ string) error {
+	return nil
+}
+
+// RedisStore server side store that keeps the values of the sessions in redis, the cookies only have the ids
+type RedisStore struct {
+	// Redis
+	redis domain.IRedis
+	// Prefix of the keys
+	prefix string
+}
+
+// NewRedisStore creates a new redis store
+func NewRedisStore(redis domain.IRedis, prefix string) *RedisStore {
+	return &RedisStore{
+		redis:  redis,
+		prefix: prefix,
+	}
+}
+
+// Load loads a session with its id
+func (s *RedisStore) Load(ctx context.Context, value string) (*Data, error) {
+	stored, err := s.redis.Client().Get(ctx, s.prefix+value).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := &Data{}
+	if err = json.Unmarshal(stored, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Save saves a session, refreshing its expiration, and gets its id
+func (s *RedisStore) Save(ctx context.Context, data *Data, expiration time.Duration) (string, error) {
+	stored, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.redis.Client().Set(ctx, s.prefix+data.Id, stored, expiration).Err(); err != nil {
+		return "", err
+	}
+	return data.Id, nil
+}
+
+// Delete deletes a session
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	return s.redis.Client().Del(ctx, s.prefix+id).Err()
+}
@@ -0,0 +1,45 @@
+package session
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writer commits the session before the headers of the response are written
+type writer struct {
+	gin.ResponseWriter
+	// Commit saves the session
+	commit func()
+	// Once
+	once sync.Once
+}
+
+// commitOnce commits the session once
+func (w *writer) commitOnce() {
+	w.once.Do(w.commit)
+}
+
+// WriteHeaderNow commits the session and writes the headers
+func (w *writer) WriteHeaderNow() {
+	w.commitOnce()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Write commits the session and writes the body
+func (w *writer) Write(data []byte) (int, error) {
+	w.commitOnce()
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString commits the session and writes the body
+func (w *writer) WriteString(s string) (int, error) {
+	w.commitOnce()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Flush commits the session and flushes the response
+func (w *writer) Flush() {
+	w.commitOnce()
+	w.ResponseWriter.Flush()
+}
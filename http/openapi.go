@@ -0,0 +1,69 @@
+package http
+
+import (
+	"html"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/openapi"
+)
+
+// swaggerUiTemplate page of the swagger ui, with the url of the document
+const swaggerUiTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8"/>
+	<title>%s</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css"/>
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+	<script>
+		window.onload = () => { window.ui = SwaggerUIBundle({ url: %q, dom_id: "#swagger-ui" }); };
+	</script>
+</body>
+</html>`
+
+// OpenApi gets the openapi document of the endpoints, generated at the start
+func (h *Http) OpenApi() *openapi.Document {
+	return h.openApi
+}
+
+// registerOpenApi generates the openapi document of the registered endpoints and serves it
+func (h *Http) registerOpenApi() {
+	cfg := h.config.OpenApi
+	if !cfg.Enabled {
+		return
+	}
+
+	title := cfg.Title
+	if title == "" {
+		title = h.app.Name()
+	}
+
+	generator := openapi.NewGenerator(openapi.Info{
+		Title:       title,
+		Description: cfg.Description,
+		Version:     cfg.Version,
+	})
+
+	for _, documented := range h.documented {
+		for _, route := range documented.DocumentedRoutes() {
+			generator.AddEndpoint(route.Method, route.Path, route.Doc)
+		}
+	}
+
+	h.openApi = generator.Document()
+
+	h.router.GET(cfg.Path, func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, h.openApi)
+	})
+
+	if cfg.SwaggerUi {
+		h.router.GET(cfg.SwaggerUiPath, func(ctx *gin.Context) {
+			ctx.Header("Content-Type", "text/html; charset=utf-8")
+			ctx.String(http.StatusOK, swaggerUiTemplate, html.EscapeString(title), cfg.Path)
+		})
+	}
+}
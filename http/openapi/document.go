@@ -0,0 +1,173 @@
+package openapi
+
+// Version version of the openapi specification of the documents
+const Version = "3.0.3"
+
+// Document openapi document
+type Document struct {
+	// OpenApi version
+	OpenApi string `json:"openapi"`
+	// Info
+	Info Info `json:"info"`
+	// Paths
+	Paths map[string]PathItem `json:"paths"`
+	// Components
+	Components Components `json:"components,omitempty"`
+}
+
+// Info information of the api
+type Info struct {
+	// Title
+	Title string `json:"title"`
+	// Description
+	Description string `json:"description,omitempty"`
+	// Version
+	Version string `json:"version"`
+}
+
+// PathItem operations of a path by method (in lower case)
+type PathItem map[string]*Operation
+
+// Operation operation of a path
+type Operation struct {
+	// Tags
+	Tags []string `json:"tags,omitempty"`
+	// Summary
+	Summary string `json:"summary,omitempty"`
+	// Description
+	Description string `json:"description,omitempty"`
+	// Operation Id
+	OperationId string `json:"operationId,omitempty"`
+	// Parameters
+	Parameters []*Parameter `json:"parameters,omitempty"`
+	// Request Body
+	RequestBody *RequestBody `json:"requestBody,omitempty"`
+	// Responses by status code
+	Responses map[string]*Response `json:"responses"`
+	// Deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// Parameter parameter of an operation
+type Parameter struct {
+	// Name
+	Name string `json:"name"`
+	// In (path, query or header)
+	In string `json:"in"`
+	// Description
+	Description string `json:"description,omitempty"`
+	// Required
+	Required bool `json:"required,omitempty"`
+	// Schema
+	Schema *Schema `json:"schema"`
+}
+
+// RequestBody body of a request
+type RequestBody struct {
+	// Description
+	Description string `json:"description,omitempty"`
+	// Required
+	Required bool `json:"required,omitempty"`
+	// Content by media type
+	Content map[string]MediaType `json:"content"`
+}
+
+// Response response of an operation
+type Response struct {
+	// Description
+	Description string `json:"description"`
+	// Content by media type
+	Content map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType content of a media type
+type MediaType struct {
+	// Schema
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components reusable objects of the document
+type Components struct {
+	// Schemas by name
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// Schema schema of a value
+type Schema struct {
+	// Ref reference to a schema of the components
+	Ref string `json:"$ref,omitempty"`
+	// Type
+	Type string `json:"type,omitempty"`
+	// Format
+	Format string `json:"format,omitempty"`
+	// Description
+	Description string `json:"description,omitempty"`
+	// Nullable
+	Nullable bool `json:"nullable,omitempty"`
+	// Enum
+	Enum []any `json:"enum,omitempty"`
+	// Minimum
+	Minimum *float64 `json:"minimum,omitempty"`
+	// Maximum
+	Maximum *float64 `json:"maximum,omitempty"`
+	// Exclusive Minimum
+	ExclusiveMinimum bool `json:"exclusiveMinimum,omitempty"`
+	// Exclusive Maximum
+	ExclusiveMaximum bool `json:"exclusiveMaximum,omitempty"`
+	// Min Length
+	MinLength *int `json:"minLength,omitempty"`
+	// Max Length
+	MaxLength *int `json:"maxLength,omitempty"`
+	// Pattern
+	Pattern string `json:"pattern,omitempty"`
+	// Min Items
+	MinItems *int `json:"minItems,omitempty"`
+	// Max Items
+	MaxItems *int `json:"maxItems,omitempty"`
+	// Items of the arrays
+	Items *Schema `json:"items,omitempty"`
+	// Properties of the objects
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// Required properties of the objects
+	Required []string `json:"required,omitempty"`
+	// AllOf schemas that the value must match
+	AllOf []*Schema `json:"allOf,omitempty"`
+	// Additional Properties of the maps
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+	// Example
+	Example any `json:"example,omitempty"`
+}
+
+// EndpointDoc documentation of an endpoint
+type EndpointDoc struct {
+	// Summary
+	Summary string
+	// Description
+	Description string
+	// Tags
+	Tags []string
+	// Params struct with the path (uri tag), query (form tag) and header (header tag) parameters
+	Params any
+	// Request body
+	Request any
+	// Responses data of the responses by status code
+	Responses map[int]any
+	// Deprecated
+	Deprecated bool
+}
+
+// Route documented route, with the method and the full path
+type Route struct {
+	// Method
+	Method string
+	// Path
+	Path string
+	// Doc
+	Doc *EndpointDoc
+}
+
+// IDocumented routes that are documented, for example a group with its endpoints
+type IDocumented interface {
+	// DocumentedRoutes gets the documented routes
+	DocumentedRoutes() []Route
+}
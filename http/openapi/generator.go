@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/guilhermealegre/go-clean-arch-core-lib/response"
+)
+
+const (
+	// contentTypeJson content type of the bodies
+	contentTypeJson = "application/json"
+	// errorResponseSchema name of the error response schema in the components
+	errorResponseSchema = "ErrorResponse"
+)
+
+// Generator generates an openapi document from the documented endpoints
+type Generator struct {
+	// Document
+	document *Document
+	// Schemas
+	schemas *schemas
+}
+
+// NewGenerator creates a new generator
+func NewGenerator(info Info) *Generator {
+	g := &Generator{
+		document: &Document{
+			OpenApi: Version,
+			Info:    info,
+			Paths:   make(map[string]PathItem),
+		},
+		schemas: newSchemas(),
+	}
+
+	g.schemas.components[errorResponseSchema] = g.errorResponse()
+	return g
+}
+
+// AddEndpoint adds an endpoint to the document
+func (g *Generator) AddEndpoint(method, path string, doc *EndpointDoc) {
+	if doc == nil {
+		doc = &EndpointDoc{}
+	}
+
+	path, pathParams := convertPath(path)
+	operation := &Operation{
+		Tags:        doc.Tags,
+		Summary:     doc.Summary,
+		Description: doc.Description,
+		OperationId: operationId(method, path),
+		Responses:   make(map[string]*Response),
+		Deprecated:  doc.Deprecated,
+	}
+
+	if doc.Params != nil {
+		operation.Parameters = g.parameters(reflect.TypeOf(doc.Params))
+	}
+
+	// the path parameters are required, even when they are not documented
+	for _, name := range pathParams {
+		if !hasParameter(operation.Parameters, name, "path") {
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+
+	if doc.Request != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{
+				contentTypeJson: {Schema: g.schemas.of(reflect.TypeOf(doc.Request))},
+			},
+		}
+	}
+
+	for status, data := range doc.Responses {
+		operation.Responses[strconv.Itoa(status)] = g.response(status, data)
+	}
+	if len(doc.Responses) == 0 {
+		operation.Responses[strconv.Itoa(http.StatusOK)] = &Response{Description: http.StatusText(http.StatusOK)}
+	}
+	operation.Responses["default"] = &Response{
+		Description: "Error",
+		Content: map[string]MediaType{
+			contentTypeJson: {Schema: &Schema{Ref: componentsPath + errorResponseSchema}},
+		},
+	}
+
+	item, ok := g.document.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		g.document.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = operation
+}
+
+// Document gets the generated document
+func (g *Generator) Document() *Document {
+	g.document.Components.Schemas = g.schemas.components
+	return g.document
+}
+
+// response gets the response of a status, with the data in the response envelope
+func (g *Generator) response(status int, data any) *Response {
+	resp := &Response{Description: http.StatusText(status)}
+	if data == nil || status == http.StatusNoContent {
+		return resp
+	}
+
+	resp.Content = map[string]MediaType{
+		contentTypeJson: {Schema: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"data":  g.schemas.of(reflect.TypeOf(data)),
+				"meta":  g.schemas.of(reflect.TypeOf(response.Meta{})),
+				"links": g.schemas.of(reflect.TypeOf(response.Links{})),
+			},
+		}},
+	}
+
+	return resp
+}
+
+// parameters gets the parameters of a struct with uri, form and header tags
+func (g *Generator) parameters(t reflect.Type) (parameters []*Parameter) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			parameters = append(parameters, g.parameters(field.Type)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		for _, location := range []struct{ tag, in string }{{"uri", "path"}, {"form", "query"}, {"header", "header"}} {
+			name, _, _ := strings.Cut(field.Tag.Get(location.tag), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+
+			parameters = append(parameters, &Parameter{
+				Name:        name,
+				In:          location.in,
+				Description: field.Tag.Get(descriptionTagName),
+				Required:    location.in == "path" || isRequired(field),
+				Schema:      g.schemas.fieldSchema(field),
+			})
+		}
+	}
+
+	return parameters
+}
+
+// convertPath converts a gin path (/users/:id/*file) to an openapi path (/users/{id}/{file})
+func convertPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationId gets the id of an operation from its method and path
+func operationId(method, path string) string {
+	var builder strings.Builder
+	builder.WriteString(strings.ToLower(method))
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '-' || r == '_' || r == '.'
+	}) {
+		builder.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+	return builder.String()
+}
+
+// hasParameter checks if a parameter exists
+func hasParameter(parameters []*Parameter, name, in string) bool {
+	for _, parameter := range parameters {
+		if parameter.Name == name && parameter.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+// errorResponse schema of the error responses, since the errors of the envelope are interfaces
+func (g *Generator) errorResponse() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"status": {Type: "integer"},
+			"errors": {Type: "array", Items: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"level": {Type: "string"},
+					"code":  {Type: "string"},
+					"error": {Type: "string"},
+					"field": {Type: "string"},
+				},
+			}},
+			"meta": g.schemas.of(reflect.TypeOf(response.Meta{})),
+		},
+		Required: []string{"status", "errors"},
+	}
+}
@@ -0,0 +1,352 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// componentsPath path of the schemas of the components
+	componentsPath = "#/components/schemas/"
+	// validateTagName tag with the validation rules
+	validateTagName = "validate"
+	// descriptionTagName tag with the description of a field
+	descriptionTagName = "description"
+	// exampleTagName tag with an example of a field
+	exampleTagName = "example"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemas generates the schemas of the go types, keeping the structs in the components
+type schemas struct {
+	// Components schemas of the structs by name
+	components map[string]*Schema
+	// Names of the structs in the components
+	names map[reflect.Type]string
+}
+
+// newSchemas creates a new schemas generator
+func newSchemas() *schemas {
+	return &schemas{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// of gets the schema of a type
+func (s *schemas) of(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	schema := s.build(t)
+	if nullable && schema.Ref == "" {
+		schema.Nullable = true
+	}
+
+	return schema
+}
+
+// build builds the schema of a type that is not a pointer
+func (s *schemas) build(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: float(0)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		return s.structRef(t)
+	default:
+		// interfaces accept any value
+		return &Schema{}
+	}
+}
+
+// structRef gets the reference of a struct in the components, adding it when missing
+func (s *schemas) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return s.structSchema(t)
+	}
+
+	name, ok := s.names[t]
+	if !ok {
+		name = s.componentName(t)
+		// the name is reserved before the fields, so that recursive structs reference themselves
+		s.names[t] = name
+		s.components[name] = &Schema{}
+		*s.components[name] = *s.structSchema(t)
+	}
+
+	return &Schema{Ref: componentsPath + name}
+}
+
+// componentName gets an unique name of a struct in the components
+func (s *schemas) componentName(t reflect.Type) string {
+	name := t.Name()
+	if _, exists := s.components[name]; !exists {
+		return name
+	}
+
+	// structs with the same name in different packages are prefixed by the package
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	name = pkg + "." + name
+
+	for i := 2; ; i++ {
+		if _, exists := s.components[name]; !exists {
+			return name
+		}
+		name = pkg + "." + t.Name() + strconv.Itoa(i)
+	}
+}
+
+// structSchema builds the schema of the fields of a struct
+func (s *schemas) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	s.addFields(schema, t)
+	return schema
+}
+
+// addFields adds the fields of a struct to a schema, flattening the embedded structs
+func (s *schemas) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			s.addFields(schema, fieldType)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := s.fieldSchema(field)
+		schema.Properties[name] = fieldSchema
+		if isRequired(field) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// fieldSchema gets the schema of a field, with its description, example and validation rules
+func (s *schemas) fieldSchema(field reflect.StructField) *Schema {
+	schema := s.of(field.Type)
+
+	description := field.Tag.Get(descriptionTagName)
+	example := field.Tag.Get(exampleTagName)
+	rules := field.Tag.Get(validateTagName)
+	if description == "" && example == "" && rules == "" {
+		return schema
+	}
+
+	// the references can not have siblings, so they are wrapped
+	if schema.Ref != "" {
+		if description == "" && example == "" {
+			return schema
+		}
+		schema = &Schema{AllOf: []*Schema{schema}, Description: description}
+		if example != "" {
+			schema.Example = example
+		}
+		return schema
+	}
+
+	schema.Description = description
+	if example != "" {
+		schema.Example = example
+	}
+	applyRules(schema, rules)
+
+	return schema
+}
+
+// jsonName gets the json name of a field, or skip when it is not serialized
+func jsonName(field reflect.StructField) (name string, skip bool) {
+	name, _, _ = strings.Cut(field.Tag.Get("json"), ",")
+	return name, name == "-"
+}
+
+// isRequired checks if a field is required by its validation rules
+func isRequired(field reflect.StructField) bool {
+	rules, _, _ := strings.Cut(field.Tag.Get(validateTagName), "dive")
+	for _, rule := range strings.Split(rules, ",") {
+		if strings.TrimSpace(rule) == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRules applies the validation rules to a schema, the rules after dive apply to the items
+func applyRules(schema *Schema, rules string) {
+	rules, itemRules, dive := strings.Cut(rules, "dive")
+	if dive && schema.Items != nil {
+		applyRules(schema.Items, strings.Trim(itemRules, ","))
+	}
+
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "min", "gte":
+			setMin(schema, param, false)
+		case "max", "lte":
+			setMax(schema, param, false)
+		case "gt":
+			setMin(schema, param, true)
+		case "lt":
+			setMax(schema, param, true)
+		case "len":
+			setMin(schema, param, false)
+			setMax(schema, param, false)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, enumValue(schema, value))
+			}
+		case "email":
+			schema.Format = "email"
+		case "url", "uri", "http_url":
+			schema.Format = "uri"
+		case "uuid", "uuid4":
+			schema.Format = "uuid"
+		case "datetime":
+			schema.Format = "date-time"
+		case "ip", "ipv4":
+			schema.Format = "ipv4"
+		case "ipv6":
+			schema.Format = "ipv6"
+		case "hostname":
+			schema.Format = "hostname"
+		case "alpha":
+			schema.Pattern = "^[a-zA-Z]*$"
+		case "alphanum":
+			schema.Pattern = "^[a-zA-Z0-9]*$"
+		case "numeric":
+			schema.Pattern = "^[-+]?[0-9]+(?:\\.[0-9]+)?$"
+		}
+	}
+}
+
+// setMin sets the minimum of a schema, according to its type
+func setMin(schema *Schema, param string, exclusive bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch schema.Type {
+	case "string":
+		length := int(value)
+		if exclusive {
+			length++
+		}
+		schema.MinLength = &length
+	case "array":
+		items := int(value)
+		if exclusive {
+			items++
+		}
+		schema.MinItems = &items
+	case "integer", "number":
+		schema.Minimum = &value
+		schema.ExclusiveMinimum = exclusive
+	}
+}
+
+// setMax sets the maximum of a schema, according to its type
+func setMax(schema *Schema, param string, exclusive bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch schema.Type {
+	case "string":
+		length := int(value)
+		if exclusive {
+			length--
+		}
+		schema.MaxLength = &length
+	case "array":
+		items := int(value)
+		if exclusive {
+			items--
+		}
+		schema.MaxItems = &items
+	case "integer", "number":
+		schema.Maximum = &value
+		schema.ExclusiveMaximum = exclusive
+	}
+}
+
+// enumValue gets a value of an enum with the type of the schema
+func enumValue(schema *Schema, value string) any {
+	switch schema.Type {
+	case "integer":
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	case "number":
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return value
+}
+
+// float gets a pointer to a float
+func float(value float64) *float64 {
+	return &value
+}
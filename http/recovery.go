@@ -3,6 +3,7 @@ package http
 // refactored from github.com/gin-gonic/gin/recovery.go
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -14,7 +15,22 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	coreErrors "github.com/guilhermealegre/go-clean-arch-core-lib/errors"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/response"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	contextDomain "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/context"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// metricPanics recovered panics count, exported with the _total suffix
+	metricPanics = "http_server_panics"
 )
 
 var (
@@ -24,76 +40,180 @@ var (
 	slash     = []byte("/")
 )
 
-// WriterFunc writer function
-type WriterFunc func(app domain.IApp, stack []byte, headers []string, isBrokenPipe bool)
+// RecoveryPolicy maps a recovered panic to the error of the response, when it handles the panic
+type RecoveryPolicy func(ctx contextDomain.IContext, recovered any) (err error, ok bool)
+
+// ErrorDetailsRecoveryPolicy replies with the errors details that are panicked, with the internal server error status by default
+func ErrorDetailsRecoveryPolicy(_ contextDomain.IContext, recovered any) (error, bool) {
+	errorDetails, ok := recovered.(coreErrors.ErrorDetails)
+	if !ok {
+		return nil, false
+	}
+	if errorDetails.StatusCode == 0 {
+		errorDetails = errorDetails.SetStatusCode(http.StatusInternalServerError)
+	}
+	return errorDetails, true
+}
+
+// recovery recovers the panics of the requests
+type recovery struct {
+	// App
+	app domain.IApp
+	// Http
+	http *Http
+	// Panics counter
+	panics metric.Int64Counter
+}
 
 // newDefaultRecovery http panic recovery
 func (h *Http) newDefaultRecovery() gin.HandlerFunc {
-	return customRecovery(h.app, writeFunc, recoveryFunc)
+	r := &recovery{
+		app:  h.app,
+		http: h,
+	}
+
+	if h.app.Meter() != nil && h.app.Meter().Prometheus() != nil {
+		// without the counter the panics are still recovered
+		r.panics, _ = h.app.Meter().Prometheus().Int64Counter(metricPanics,
+			metric.WithDescription("Number of panics recovered from the HTTP requests"))
+	}
+
+	return r.handle
 }
 
-// recoveryFunc recovery function
-func recoveryFunc(c *gin.Context, err interface{}) {
-	c.AbortWithStatus(http.StatusInternalServerError)
+// WithRecoveryPolicy adds policies that map the recovered panics to the errors of the responses,
+// which are applied in order before the default internal server error
+func (h *Http) WithRecoveryPolicy(policies ...RecoveryPolicy) domain.IHttp {
+	h.recoveryPolicies = append(h.recoveryPolicies, policies...)
+	return h
 }
 
-// writeFunc write function
-func writeFunc(app domain.IApp, stack []byte, headers []string, isBrokenPipe bool) {
-	if len(headers) > 2 {
-		headers = headers[:2]
+// handle recovers the panics, replying with the error of the policies and logging it with the request context
+func (r *recovery) handle(c *gin.Context) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		// Check for a broken connection, as it is not really a
+		// condition that warrants a panic stack trace.
+		brokenPipe := isBrokenPipe(recovered)
+
+		ctx := context.NewContext(c)
+		errResponse := r.responseError(ctx, recovered)
+		statusCode, resp := response.GetResponse(nil, nil, nil, errResponse)
+
+		r.log(c, ctx, recovered, brokenPipe, statusCode, resp)
+
+		if r.panics != nil {
+			r.panics.Add(c.Request.Context(), 1, metric.WithAttributes(
+				attribute.String(metricLabelMethod, c.Request.Method),
+				attribute.String(metricLabelRoute, c.FullPath()),
+			))
+		}
+
+		if brokenPipe {
+			// If the connection is dead, we can't write a status to it.
+			if err, ok := recovered.(error); ok {
+				c.Error(err) // nolint: errcheck
+			}
+			c.Abort()
+			return
+		}
+
+		c.AbortWithStatusJSON(statusCode, resp)
+	}()
+
+	c.Next()
+}
+
+// responseError gets the error of the response from the policies
+func (r *recovery) responseError(ctx contextDomain.IContext, recovered any) error {
+	for _, policy := range r.http.recoveryPolicies {
+		if err, ok := policy(ctx, recovered); ok {
+			return err
+		}
 	}
+	return errorCodes.ErrorPanicRecovered().SetStatusCode(http.StatusInternalServerError)
+}
+
+// log logs a panic synchronously, with the request context, the trace and, when configured, the stack and the body
+func (r *recovery) log(c *gin.Context, ctx contextDomain.IContext, recovered any, brokenPipe bool, statusCode int, resp any) {
+	cfg := r.http.config.Recovery
 
 	brokenPipeText := ""
-	if isBrokenPipe {
+	if brokenPipe {
 		brokenPipeText = " : Broken Pipe"
 	}
 
-	text := fmt.Sprintf("[Recovery%s] %s panic recovered:\n%s\n%s",
+	httpRequest, _ := httputil.DumpRequest(c.Request, false)
+	headers := strings.Split(string(httpRequest), "\r\n")
+	if len(headers) > 2 {
+		headers = headers[:2]
+	}
+
+	text := fmt.Sprintf("[Recovery%s] %s panic recovered: %v\n%s",
 		brokenPipeText,
 		time.Now(),
-		strings.Join(headers, "\r\n"),
-		stack)
+		recovered,
+		strings.Join(headers, "\r\n"))
+	if body := capture.RequestBody(ctx); cfg.IncludeBody && body != nil {
+		text += fmt.Sprintf("\n%s", body)
+	}
+	if !cfg.DisableStack {
+		text += fmt.Sprintf("\n%s", stack(4))
+	}
 
 	// write to stderr
-	_, _ = gin.DefaultErrorWriter.Write([]byte(text))
+	_, _ = gin.DefaultErrorWriter.Write([]byte(text + "\n"))
+
+	if r.app.Logger() == nil || r.app.Logger().Log() == nil {
+		return
+	}
 
-	// write to some place
-	app.Logger().Log().Do(errors.New(text))
+	body, _ := json.Marshal(resp)
+	r.app.Logger().Log().Do(
+		errors.New(text),
+		&domain.LoggerInfo{
+			Context: ctx,
+			TraceId: c.GetString(context.CtxTraceId),
+			Response: domain.Response{
+				StatusCode: statusCode,
+				Body:       string(body),
+			},
+		},
+	)
 }
 
-// customRecovery returns a middleware for a given writer that recovers from any panics and calls the provided handle func to handle it.
-func customRecovery(app domain.IApp, writer WriterFunc, recoveryFunc gin.RecoveryFunc) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		defer func() {
-			if err := recover(); err != nil {
-				// Check for a broken connection, as it is not really a
-				// condition that warrants a panic stack trace.
-				var brokenPipe bool
-				if ne, ok := err.(*net.OpError); ok {
-					if se, ok := ne.Err.(*os.SyscallError); ok {
-						if strings.Contains(strings.ToLower(se.Error()), "broken pipe") || strings.Contains(strings.ToLower(se.Error()), "connection reset by peer") {
-							brokenPipe = true
-						}
-					}
-				}
-				httpRequest, _ := httputil.DumpRequest(c.Request, false)
-				headers := strings.Split(string(httpRequest), "\r\n")
-
-				// write error
-				go writer(app, stack(3), headers, brokenPipe)
-
-				if brokenPipe {
-					// If the connection is dead, we can't write a status to it.
-					c.Error(err.(error)) // nolint: errcheck
-					c.Abort()
-				} else {
-					recoveryFunc(c, err)
-				}
+// tracePanics marks the span of the request as errored when a panic happens, before the span ends,
+// keeping the trace id to the recovery
+func tracePanics(c *gin.Context) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			span := trace.SpanFromContext(c.Request.Context())
+			if span.SpanContext().IsValid() {
+				span.RecordError(fmt.Errorf("panic: %v", recovered))
+				span.SetStatus(codes.Error, "panic recovered")
+				c.Set(context.CtxTraceId, span.SpanContext().TraceID().String())
 			}
-		}()
+			panic(recovered)
+		}
+	}()
+
+	c.Next()
+}
 
-		c.Next()
+// isBrokenPipe checks if a panic is caused by a broken connection
+func isBrokenPipe(recovered any) bool {
+	if ne, ok := recovered.(*net.OpError); ok {
+		if se, ok := ne.Err.(*os.SyscallError); ok {
+			if strings.Contains(strings.ToLower(se.Error()), "broken pipe") || strings.Contains(strings.ToLower(se.Error()), "connection reset by peer") {
+				return true
+			}
+		}
 	}
+	return false
 }
 
 // stack returns a nicely formatted stack frame, skipping skip frames.
@@ -2,21 +2,37 @@ package http
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-core-lib/response"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
 )
 
 // loadRequestInfo loads the request information to domain context
-func loadRequestInfo(gCtx *gin.Context) {
+func (h *Http) loadRequestInfo(gCtx *gin.Context) {
 	if gCtx.Request != nil {
 		ctx := context.NewContext(gCtx)
+		// request id, returned in the response
+		id := gCtx.GetHeader(context.HeaderRequestId)
+		if !context.ValidRequestId(id) {
+			id = context.NewRequestId()
+		}
+		ctx.SetRequestId(id)
+		// capture policy of the bodies of the logs and the traces
+		ctx.Set(context.CtxCapture, h.capture)
+		gCtx.Header(context.HeaderRequestId, id)
 		// method
 		ctx.SetMethod(gCtx.Request.Method)
 		//path
+		var path string
 		if gCtx.Request.URL != nil {
-			ctx.SetPath(gCtx.Request.URL.Path)
+			path = gCtx.Request.URL.Path
+			ctx.SetPath(path)
 			// params
 			if gCtx.Request.URL.Query() != nil {
 				params := make(map[string]any)
@@ -32,14 +48,54 @@ func loadRequestInfo(gCtx *gin.Context) {
 		}
 		// body
 		if gCtx.Request.Body != nil {
-			body, _ := io.ReadAll(gCtx.Request.Body)
+			limit := h.bodyLimit(gCtx.Request.Method, gCtx.FullPath())
+			// the declared length is rejected before reading the body
+			if limit >= 0 && gCtx.Request.ContentLength > limit {
+				abortBodyTooLarge(gCtx, limit)
+				return
+			}
+
+			body := gCtx.Request.Body
+			if limit >= 0 {
+				body = http.MaxBytesReader(gCtx.Writer, gCtx.Request.Body, limit)
+			}
+
+			// the multipart and binary bodies are streamed to the handlers, without buffering
+			if !h.capture.Buffers(gCtx.ContentType()) {
+				gCtx.Request.Body = body
+				gCtx.Next()
+				return
+			}
+
+			data, err := io.ReadAll(body)
+			var errMaxBytes *http.MaxBytesError
+			if errors.As(err, &errMaxBytes) {
+				abortBodyTooLarge(gCtx, limit)
+				return
+			}
 			// resetting the body buffer to the request
-			gCtx.Request.Body = NewReader(bytes.NewBuffer(body), true)
+			gCtx.Request.Body = NewReader(bytes.NewBuffer(data), true)
 			// setting the body as bytes, so it can be read multiple times
-			if len(body) > 0 {
-				ctx.SetBody(body)
+			if len(data) > 0 {
+				ctx.SetBody(data)
 			}
 		}
 	}
 	gCtx.Next()
 }
+
+// bodyLimit gets the body limit of a route, without limit when negative
+func (h *Http) bodyLimit(method, path string) int64 {
+	for _, route := range h.config.BodyLimit.Routes {
+		if route.Path == path && (route.Method == "" || strings.EqualFold(route.Method, method)) {
+			return route.MaxBytes
+		}
+	}
+	return h.config.BodyLimit.MaxBytes
+}
+
+// abortBodyTooLarge aborts the request whose body exceeds the limit
+func abortBodyTooLarge(gCtx *gin.Context, limit int64) {
+	err := errorCodes.ErrorRequestBodyTooLarge().Formats(limit).SetStatusCode(http.StatusRequestEntityTooLarge)
+	gCtx.AbortWithStatusJSON(response.GetResponse(nil, nil, nil, err))
+}
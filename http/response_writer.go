@@ -2,30 +2,74 @@ package http
 
 import (
 	"bytes"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
 )
 
-// responseWriter is a wrapper of gin.ResponseWriter
+// responseWriter is a wrapper of gin.ResponseWriter that captures the body allowed by the capture policy
 type responseWriter struct {
 	gin.ResponseWriter
 	Body *bytes.Buffer
+	// Capture checks if the body is captured, decided on the first write
+	capture func(contentType string) bool
+	// Max Bytes captured
+	maxBytes int
+	// Decided if the body is captured
+	decided bool
+	// Captured
+	captured bool
+	// Omitted bytes after the max bytes
+	omitted int
 }
 
 // newResponseWriter returns a responseWriter
-func newResponseWriter(gCtx *gin.Context) *responseWriter {
+func newResponseWriter(gCtx *gin.Context, policy *capture.Policy) *responseWriter {
 	return &responseWriter{
 		ResponseWriter: gCtx.Writer,
 		Body:           new(bytes.Buffer),
+		capture: func(contentType string) bool {
+			return policy.Captures(gCtx.Request.Method, gCtx.FullPath(), gCtx.Request.URL.Path, contentType)
+		},
+		maxBytes: policy.MaxBytes(),
 	}
 }
 
 // Write captures the body
 func (w *responseWriter) Write(b []byte) (int, error) {
-	w.Body.Write(b)
+	w.captureBody(b)
 	return w.ResponseWriter.Write(b)
 }
 
+// WriteString captures the body
+func (w *responseWriter) WriteString(s string) (int, error) {
+	w.captureBody([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// captureBody captures the bytes until the max bytes
+func (w *responseWriter) captureBody(b []byte) {
+	if !w.decided {
+		contentType := w.Header().Get("Content-Type")
+		if contentType == "" {
+			contentType = http.DetectContentType(b)
+		}
+		w.captured = w.capture(contentType)
+		w.decided = true
+	}
+
+	if !w.captured {
+		return
+	}
+
+	if available := w.maxBytes - w.Body.Len(); available < len(b) {
+		w.omitted += len(b) - max(available, 0)
+		b = b[:max(available, 0)]
+	}
+	w.Body.Write(b)
+}
+
 // captureResponseWriter captures the response writer
 func (w *responseWriter) captureResponseWriter(gCtx *gin.Context) {
 	gCtx.Writer = w
@@ -33,5 +77,5 @@ func (w *responseWriter) captureResponseWriter(gCtx *gin.Context) {
 
 // getBody returns the response body
 func (w *responseWriter) getBody() string {
-	return w.Body.String()
+	return string(capture.Truncated(w.Body.Bytes(), w.omitted))
 }
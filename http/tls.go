@@ -0,0 +1,190 @@
+package http
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	httpConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http/config"
+)
+
+const (
+	// certExtension extension of the certificates of the directory
+	certExtension = ".crt"
+	// keyExtension extension of the keys of the directory
+	keyExtension = ".key"
+)
+
+// tlsVersions tls versions by name
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// certificatePair files of a certificate
+type certificatePair struct {
+	certFile string
+	keyFile  string
+}
+
+// certificates certificates loaded from files, which are reloaded when the files change
+type certificates struct {
+	// Pairs of files
+	pairs []certificatePair
+	// Dir of the pairs, listed again when reloaded
+	dir string
+	// Reload Interval
+	reloadInterval time.Duration
+	// Certificates
+	certificates []*tls.Certificate
+	// Modified At of the files when loaded
+	modifiedAt map[string]time.Time
+	// Checked At last check of the files
+	checkedAt time.Time
+	// Mutex
+	mux sync.RWMutex
+}
+
+// newTlsConfig creates the tls configurations of the server
+func newTlsConfig(cfg httpConfig.TlsConfig) (*tls.Config, error) {
+	certs := &certificates{
+		dir:            cfg.CertDir,
+		reloadInterval: time.Duration(cfg.ReloadSeconds) * time.Second,
+	}
+
+	switch {
+	case cfg.CertFile != "" && cfg.KeyFile != "":
+		certs.pairs = []certificatePair{{certFile: cfg.CertFile, keyFile: cfg.KeyFile}}
+	case cfg.CertDir == "":
+		return nil, errors.ErrorInvalidTlsConfig().Formats("the cert and key files or the cert dir are required")
+	}
+
+	if err := certs.load(); err != nil {
+		return nil, errors.ErrorInvalidTlsConfig().Formats(err)
+	}
+
+	return &tls.Config{
+		MinVersion:     tlsVersions[cfg.MinVersion],
+		GetCertificate: certs.get,
+	}, nil
+}
+
+// get gets the certificate of the server name of a handshake
+func (c *certificates) get(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.reload(time.Now())
+
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	for _, cert := range c.certificates {
+		if hello.SupportsCertificate(cert) == nil {
+			return cert, nil
+		}
+	}
+
+	// the clients without server name get the first certificate
+	return c.certificates[0], nil
+}
+
+// reload loads the certificates again when the files changed, keeping the previous ones if they are invalid
+func (c *certificates) reload(now time.Time) {
+	if c.reloadInterval <= 0 {
+		return
+	}
+
+	c.mux.Lock()
+	if now.Sub(c.checkedAt) < c.reloadInterval {
+		c.mux.Unlock()
+		return
+	}
+	c.checkedAt = now
+	changed := c.changed()
+	c.mux.Unlock()
+
+	if changed {
+		_ = c.load()
+	}
+}
+
+// changed checks if the files changed since they were loaded
+func (c *certificates) changed() bool {
+	pairs, err := c.listPairs()
+	if err != nil {
+		return false
+	}
+	if len(pairs) != len(c.pairs) {
+		return true
+	}
+
+	for _, pair := range pairs {
+		for _, file := range []string{pair.certFile, pair.keyFile} {
+			info, err := os.Stat(file)
+			if err == nil && !info.ModTime().Equal(c.modifiedAt[file]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// load loads the certificates of the files
+func (c *certificates) load() error {
+	pairs, err := c.listPairs()
+	if err != nil {
+		return err
+	}
+	if len(pairs) == 0 {
+		return fmt.Errorf("no certificates in %s", c.dir)
+	}
+
+	certs := make([]*tls.Certificate, 0, len(pairs))
+	modifiedAt := make(map[string]time.Time)
+	for _, pair := range pairs {
+		cert, err := tls.LoadX509KeyPair(pair.certFile, pair.keyFile)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, &cert)
+
+		for _, file := range []string{pair.certFile, pair.keyFile} {
+			if info, err := os.Stat(file); err == nil {
+				modifiedAt[file] = info.ModTime()
+			}
+		}
+	}
+
+	c.mux.Lock()
+	c.pairs = pairs
+	c.certificates = certs
+	c.modifiedAt = modifiedAt
+	c.mux.Unlock()
+
+	return nil
+}
+
+// listPairs lists the pairs of files, from the directory when it is set
+func (c *certificates) listPairs() ([]certificatePair, error) {
+	if c.dir == "" {
+		return c.pairs, nil
+	}
+
+	certFiles, err := filepath.Glob(filepath.Join(c.dir, "*"+certExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]certificatePair, 0, len(certFiles))
+	for _, certFile := range certFiles {
+		keyFile := strings.TrimSuffix(certFile, certExtension) + keyExtension
+		if _, err = os.Stat(keyFile); err == nil {
+			pairs = append(pairs, certificatePair{certFile: certFile, keyFile: keyFile})
+		}
+	}
+
+	return pairs, nil
+}
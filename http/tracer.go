@@ -2,6 +2,7 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/tracer"
 )
@@ -10,19 +11,21 @@ import (
 func (h *Http) traceRequest(gCtx *gin.Context) {
 	ctx := context.NewContext(gCtx)
 	attrs := make(map[string]any)
-	if ctx.GetBody() != nil {
-		attrs[tracer.TracerTagRequestBody] = string(ctx.GetBody())
+	if body := capture.RequestBody(ctx); body != nil {
+		attrs[tracer.TracerTagRequestBody] = string(body)
 	}
 	if ctx.GetParams() != nil {
 		attrs[tracer.TracerTagParams] = ctx.GetParams()
 	}
 
-	writer := newResponseWriter(gCtx)
+	writer := newResponseWriter(gCtx, h.capture)
 	writer.captureResponseWriter(gCtx)
 
 	gCtx.Next()
 
-	attrs[tracer.TracerTagResponseBody] = writer.getBody()
+	if body := writer.getBody(); body != "" {
+		attrs[tracer.TracerTagResponseBody] = body
+	}
 
 	h.app.Tracer().TraceCurrentSpan(ctx.RequestContext(), attrs, nil)
 }
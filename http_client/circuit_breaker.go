@@ -0,0 +1,89 @@
+package http_client
+
+import (
+	"sync"
+	"time"
+)
+
+// circuit states
+const (
+	circuitClosed = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker stops the requests to an upstream after consecutive failures,
+// allowing a single request to check the upstream after the open time
+type circuitBreaker struct {
+	// Failure Threshold
+	failureThreshold int
+	// Open Time
+	openTime time.Duration
+	// State
+	state int
+	// Failures consecutive failures
+	failures int
+	// Opened At
+	openedAt time.Time
+	// Mutex
+	mux sync.Mutex
+}
+
+// newCircuitBreaker creates a new circuit breaker
+func newCircuitBreaker(failureThreshold int, openTime time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		openTime:         openTime,
+	}
+}
+
+// allow checks if a request is allowed
+func (c *circuitBreaker) allow(now time.Time) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	switch c.state {
+	case circuitOpen:
+		if now.Sub(c.openedAt) < c.openTime {
+			return false
+		}
+		c.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// only the checking request is allowed
+		return false
+	default:
+		return true
+	}
+}
+
+// success records a successful request, closing the circuit
+func (c *circuitBreaker) success() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.state = circuitClosed
+	c.failures = 0
+}
+
+// failure records a failed request, opening the circuit after the threshold or when the check fails
+func (c *circuitBreaker) failure(now time.Time) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.failures++
+	if c.state == circuitHalfOpen || c.failures >= c.failureThreshold {
+		c.state = circuitOpen
+		c.openedAt = now
+	}
+}
+
+// cancel releases the checking request that finished without a result, such as a canceled context
+func (c *circuitBreaker) cancel() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.state == circuitHalfOpen {
+		c.state = circuitOpen
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+// Auth types
+const (
+	AuthBasic  = "basic"
+	AuthBearer = "bearer"
+	AuthApiKey = "apiKey"
+)
+
+// Config http client configurations
+type Config struct {
+	// Upstreams by name
+	Upstreams map[string]*Upstream `yaml:"upstreams" validate:"dive,required"`
+	// Additional Config
+	AdditionalConfig interface{} `yaml:"additionalConfig"`
+}
+
+// Upstream configurations of an upstream api
+type Upstream struct {
+	// Base Url of the requests
+	BaseUrl string `yaml:"baseUrl" validate:"required,url"`
+	// Connect Timeout Milliseconds
+	ConnectTimeoutMilliseconds int `yaml:"connectTimeoutMilliseconds" default:"2000" validate:"min=0"`
+	// Timeout Milliseconds of each attempt until the response body is closed, without timeout when negative
+	TimeoutMilliseconds int `yaml:"timeoutMilliseconds" default:"10000" validate:"min=-1"`
+	// Max Idle Connections
+	MaxIdleConnections int `yaml:"maxIdleConnections" default:"100" validate:"min=0"`
+	// Headers sent in every request
+	Headers map[string]string `yaml:"headers"`
+	// Auth
+	Auth AuthConfig `yaml:"auth"`
+	// Retry
+	Retry RetryConfig `yaml:"retry"`
+	// Circuit Breaker
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuitBreaker"`
+}
+
+// AuthConfig authentication of the requests
+type AuthConfig struct {
+	// Type (basic, bearer or apiKey), without authentication when empty
+	Type string `yaml:"type" validate:"omitempty,oneof=basic bearer apiKey"`
+	// Username of the basic authentication
+	Username string `yaml:"username"`
+	// Password of the basic authentication
+	Password string `yaml:"password"`
+	// Token of the bearer authentication
+	Token string `yaml:"token"`
+	// Header of the api key authentication
+	Header string `yaml:"header" default:"X-Api-Key"`
+	// Key of the api key authentication
+	Key string `yaml:"key"`
+}
+
+// RetryConfig retries of the failed requests
+type RetryConfig struct {
+	// Max Retries, without retries when zero
+	MaxRetries int `yaml:"maxRetries" validate:"min=0"`
+	// Initial Backoff Milliseconds, doubled in each retry
+	InitialBackoffMilliseconds int `yaml:"initialBackoffMilliseconds" default:"100" validate:"min=0"`
+	// Max Backoff Milliseconds
+	MaxBackoffMilliseconds int `yaml:"maxBackoffMilliseconds" default:"2000" validate:"min=0"`
+	// Jitter fraction of the backoff that is random, between 0 and 1, without jitter when negative
+	Jitter float64 `yaml:"jitter" default:"0.5" validate:"min=-1,max=1"`
+	// Status Codes that are retried, besides the connection errors
+	StatusCodes []int `yaml:"statusCodes" default:"429,502,503,504"`
+	// Methods that are retried, only the idempotent methods by default
+	Methods []string `yaml:"methods" default:"GET,HEAD,OPTIONS,PUT,DELETE"`
+}
+
+// CircuitBreakerConfig circuit breaker of the upstream
+type CircuitBreakerConfig struct {
+	// Enabled
+	Enabled bool `yaml:"enabled"`
+	// Failure Threshold consecutive failures that open the circuit
+	FailureThreshold int `yaml:"failureThreshold" default:"5" validate:"min=1"`
+	// Open Seconds until a request is allowed to check the upstream
+	OpenSeconds int `yaml:"openSeconds" default:"30" validate:"min=1"`
+}
@@ -0,0 +1,141 @@
+package http_client
+
+import (
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/config"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	httpClientConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http_client/config"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// HttpClient service of the clients of the upstream apis
+type HttpClient struct {
+	// Name
+	name string
+	// App
+	app domain.IApp
+	// Configuration
+	config *httpClientConfig.Config
+	// Upstreams
+	upstreams map[string]*Upstream
+	// Additional Config Type
+	additionalConfigType interface{}
+	// Started
+	started bool
+}
+
+const (
+	// configFile http client configuration file
+	configFile = "http_client.yaml"
+)
+
+// New creates a new http client service
+func New(app domain.IApp, config *httpClientConfig.Config) *HttpClient {
+	h := &HttpClient{
+		name:      "Http Client",
+		app:       app,
+		upstreams: make(map[string]*Upstream),
+	}
+
+	if config != nil {
+		h.config = config
+	}
+
+	return h
+}
+
+// Name gets the service name
+func (h *HttpClient) Name() string {
+	return h.name
+}
+
+// DependsOn the requests are traced and measured as soon as the service starts
+func (h *HttpClient) DependsOn() (services []domain.IService) {
+	if h.app.Tracer() != nil {
+		services = append(services, h.app.Tracer())
+	}
+	if h.app.Meter() != nil {
+		services = append(services, h.app.Meter())
+	}
+	return services
+}
+
+// Start starts the http client service
+func (h *HttpClient) Start() (err error) {
+	if h.config == nil {
+		h.config = &httpClientConfig.Config{}
+		h.config.AdditionalConfig = h.additionalConfigType
+		if err = config.Load(h.ConfigFile(), h.config); err != nil {
+			err = errors.ErrorLoadingConfigFile().Formats(h.ConfigFile(), err)
+			message.ErrorMessage(h.Name(), err)
+			return err
+		}
+	}
+
+	var meter metric.Meter
+	if h.app.Meter() != nil {
+		meter = h.app.Meter().Prometheus()
+	}
+
+	metrics, err := newClientMetrics(meter)
+	if err != nil {
+		return err
+	}
+
+	for name, upstreamConfig := range h.config.Upstreams {
+		upstream, errUpstream := newUpstream(h.app, h.name, name, upstreamConfig, metrics)
+		if errUpstream != nil {
+			message.ErrorMessage(h.Name(), errUpstream)
+			return errUpstream
+		}
+		h.upstreams[name] = upstream
+	}
+
+	h.started = true
+
+	return nil
+}
+
+// Stop stops the http client service
+func (h *HttpClient) Stop() error {
+	if !h.started {
+		return nil
+	}
+	h.started = false
+
+	for _, upstream := range h.upstreams {
+		upstream.client.CloseIdleConnections()
+	}
+
+	return nil
+}
+
+// Upstream gets the client of an upstream
+func (h *HttpClient) Upstream(name string) domain.IHttpUpstream {
+	if upstream, ok := h.upstreams[name]; ok {
+		return upstream
+	}
+	return nil
+}
+
+// Config gets the configurations
+func (h *HttpClient) Config() *httpClientConfig.Config {
+	return h.config
+}
+
+// ConfigFile gets the configuration file
+func (h *HttpClient) ConfigFile() string {
+	return configFile
+}
+
+// WithAdditionalConfigType sets an additional config type
+func (h *HttpClient) WithAdditionalConfigType(obj interface{}) domain.IHttpClient {
+	h.additionalConfigType = obj
+	return h
+}
+
+// Started true if started
+func (h *HttpClient) Started() bool {
+	return h.started
+}
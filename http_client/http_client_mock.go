@@ -0,0 +1,105 @@
+package http_client
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	httpClientConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http_client/config"
+	"github.com/stretchr/testify/mock"
+)
+
+func NewHttpClientMock() *HttpClientMock {
+	return &HttpClientMock{}
+}
+
+type HttpClientMock struct {
+	mock.Mock
+}
+
+func (h *HttpClientMock) Name() string {
+	args := h.Called()
+	return args.Get(0).(string)
+}
+
+func (h *HttpClientMock) Start() error {
+	args := h.Called()
+	return args.Error(0)
+}
+
+func (h *HttpClientMock) Stop() error {
+	args := h.Called()
+	return args.Error(0)
+}
+
+func (h *HttpClientMock) Started() bool {
+	args := h.Called()
+	return args.Bool(0)
+}
+
+func (h *HttpClientMock) WithAdditionalConfigType(obj interface{}) domain.IHttpClient {
+	args := h.Called(obj)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(domain.IHttpClient)
+}
+
+func (h *HttpClientMock) ConfigFile() string {
+	args := h.Called()
+	return args.Get(0).(string)
+}
+
+func (h *HttpClientMock) Config() *httpClientConfig.Config {
+	args := h.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*httpClientConfig.Config)
+}
+
+func (h *HttpClientMock) Upstream(name string) domain.IHttpUpstream {
+	args := h.Called(name)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(domain.IHttpUpstream)
+}
+
+func NewUpstreamMock() *UpstreamMock {
+	return &UpstreamMock{}
+}
+
+type UpstreamMock struct {
+	mock.Mock
+}
+
+func (u *UpstreamMock) Client() *http.Client {
+	args := u.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*http.Client)
+}
+
+func (u *UpstreamMock) NewRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	args := u.Called(ctx, method, path, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*http.Request), args.Error(1)
+}
+
+func (u *UpstreamMock) Do(req *http.Request) (*http.Response, error) {
+	args := u.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func (u *UpstreamMock) DoJson(ctx context.Context, method, path string, body any, response any) error {
+	args := u.Called(ctx, method, path, body, response)
+	return args.Error(0)
+}
@@ -0,0 +1,74 @@
+package http_client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// metricRequests requests count, exported with the _total suffix
+	metricRequests = "http_client_requests"
+	// metricDuration requests latency, exported with the _seconds suffix
+	metricDuration = "http_client_request_duration"
+
+	// metricLabelUpstream upstream label
+	metricLabelUpstream = "upstream"
+	// metricLabelMethod method label
+	metricLabelMethod = "method"
+	// metricLabelStatusClass status class label (2xx, 4xx, ...), error when the request failed
+	metricLabelStatusClass = "status_class"
+	// statusClassError status class of the failed requests
+	statusClassError = "error"
+)
+
+// clientMetrics metrics of the requests to the upstreams
+type clientMetrics struct {
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// newClientMetrics creates the instruments of the requests metrics, without metrics when the meter is missing
+func newClientMetrics(meter metric.Meter) (m *clientMetrics, err error) {
+	if meter == nil {
+		return nil, nil
+	}
+
+	m = &clientMetrics{}
+	if m.requests, err = meter.Int64Counter(metricRequests,
+		metric.WithDescription("Number of HTTP requests to the upstreams")); err != nil {
+		return nil, err
+	}
+
+	if m.duration, err = meter.Float64Histogram(metricDuration,
+		metric.WithDescription("Duration of the HTTP requests to the upstreams"),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// record records the metrics of a request
+func (m *clientMetrics) record(ctx context.Context, upstream, method string, resp *http.Response, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	statusClass := statusClassError
+	if resp != nil {
+		statusClass = fmt.Sprintf("%dxx", resp.StatusCode/100)
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String(metricLabelUpstream, upstream),
+		attribute.String(metricLabelMethod, method),
+		attribute.String(metricLabelStatusClass, statusClass),
+	)
+	m.requests.Add(ctx, 1, attrs)
+	m.duration.Record(ctx, duration.Seconds(), attrs)
+}
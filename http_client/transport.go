@@ -0,0 +1,351 @@
+package http_client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	httpClientConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http_client/config"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/tracer"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// maxTracedBody max size of the bodies in the spans
+	maxTracedBody = 64 * 1024
+	// retryAttemptKey key of the attempt of a request in the context
+	retryAttemptKey = retryAttempt("attempt")
+)
+
+// retryAttempt type of the key of the attempt in the context
+type retryAttempt string
+
+// headersTransport sets the default headers and the authentication of the upstream
+type headersTransport struct {
+	config *httpClientConfig.Upstream
+	next   http.RoundTripper
+}
+
+// RoundTrip sends a request
+func (t *headersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, value := range t.config.Headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	auth := t.config.Auth
+	switch auth.Type {
+	case httpClientConfig.AuthBasic:
+		req.SetBasicAuth(auth.Username, auth.Password)
+	case httpClientConfig.AuthBearer:
+		req.Header.Set("Authorization", "Bearer "+auth.Token)
+	case httpClientConfig.AuthApiKey:
+		req.Header.Set(auth.Header, auth.Key)
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// retryTransport retries the failed requests with backoff, through the circuit breaker
+type retryTransport struct {
+	name    string
+	config  *httpClientConfig.RetryConfig
+	breaker *circuitBreaker
+	methods map[string]bool
+	status  map[int]bool
+	next    http.RoundTripper
+}
+
+// newRetryTransport creates a new retry transport
+func newRetryTransport(name string, config *httpClientConfig.RetryConfig, breaker *circuitBreaker, next http.RoundTripper) *retryTransport {
+	t := &retryTransport{
+		name:    name,
+		config:  config,
+		breaker: breaker,
+		methods: make(map[string]bool),
+		status:  make(map[int]bool),
+		next:    next,
+	}
+	for _, method := range config.Methods {
+		t.methods[method] = true
+	}
+	for _, status := range config.StatusCodes {
+		t.status[status] = true
+	}
+	return t
+}
+
+// RoundTrip sends a request, retrying it while it fails
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	// the bodies that can not be read again are not retried
+	retryable := t.methods[req.Method] && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		if t.breaker != nil && !t.breaker.allow(time.Now()) {
+			return nil, errorCodes.ErrorHttpClientCircuitOpen().Formats(t.name).
+				SetStatusCode(http.StatusServiceUnavailable)
+		}
+
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(context.WithValue(ctx, retryAttemptKey, attempt))
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		t.record(ctx, resp, err)
+
+		if attempt >= t.config.MaxRetries || !retryable || ctx.Err() != nil || !t.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// record records the result of a request in the circuit breaker
+func (t *retryTransport) record(ctx context.Context, resp *http.Response, err error) {
+	if t.breaker == nil {
+		return
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		t.breaker.cancel()
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		t.breaker.failure(time.Now())
+	default:
+		t.breaker.success()
+	}
+}
+
+// shouldRetry checks if a request should be retried
+func (t *retryTransport) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return t.status[resp.StatusCode]
+}
+
+// backoff gets the time to wait before a retry, with exponential backoff and jitter,
+// respecting the retry after of the upstream up to the max backoff
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	maxBackoff := time.Duration(t.config.MaxBackoffMilliseconds) * time.Millisecond
+	wait := time.Duration(t.config.InitialBackoffMilliseconds) * time.Millisecond << attempt
+	if wait > maxBackoff || wait <= 0 {
+		wait = maxBackoff
+	}
+	if t.config.Jitter > 0 {
+		wait -= time.Duration(rand.Float64() * t.config.Jitter * float64(wait))
+	}
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			retryAfter := time.Duration(seconds) * time.Second
+			if retryAfter > wait {
+				wait = retryAfter
+			}
+			if wait > maxBackoff {
+				wait = maxBackoff
+			}
+		}
+	}
+
+	return wait
+}
+
+// timeoutTransport limits each attempt, until the response body is closed
+type timeoutTransport struct {
+	timeout time.Duration
+	next    http.RoundTripper
+}
+
+// RoundTrip sends a request
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody body that cancels the context of the attempt when it is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// tracerTransport traces and measures the requests, propagating the context to the upstream
+type tracerTransport struct {
+	app     domain.IApp
+	name    string
+	metrics *clientMetrics
+	next    http.RoundTripper
+}
+
+// RoundTrip sends a request
+func (t *tracerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(t.app.Name()).Start(req.Context(),
+		fmt.Sprintf("%s %s", t.name, req.Method),
+		trace.WithSpanKind(trace.SpanKindClient))
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	if id := contextInfra.RequestId(ctx); id != "" && req.Header.Get(contextInfra.HeaderRequestId) == "" {
+		req.Header.Set(contextInfra.HeaderRequestId, id)
+	}
+
+	traced, tracedBodies := t.traces(req)
+
+	attrs := map[string]any{
+		tracer.TracerTagUpstream:   t.name,
+		tracer.TracerTagHttpMethod: req.Method,
+		tracer.TracerTagHttpUrl:    tracedUrl(req.URL),
+	}
+	if attempt, ok := ctx.Value(retryAttemptKey).(int); ok {
+		attrs[tracer.TracerTagRetry] = attempt
+	}
+	if tracedBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			attrs[tracer.TracerTagRequestBody] = string(readTraced(body))
+			_ = body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	t.metrics.record(ctx, t.name, req.Method, resp, time.Since(start))
+
+	traceErr := err
+	if resp != nil {
+		attrs[tracer.TracerTagHttpStatus] = resp.StatusCode
+		if resp.StatusCode >= http.StatusInternalServerError {
+			traceErr = errorCodes.ErrorHttpClientStatus().Formats(t.name, resp.StatusCode)
+		}
+	}
+
+	// the bodies are traced unless the method and path of the upstream are sensitive
+	traceCtx := context.WithValue(ctx, contextInfra.CtxMethod, req.Method)     // nolint: staticcheck
+	traceCtx = context.WithValue(traceCtx, contextInfra.CtxPath, req.URL.Path) // nolint: staticcheck
+	finish := func() {
+		if traced {
+			t.app.Tracer().TraceCurrentSpan(traceCtx, attrs, traceErr)
+		}
+		span.End()
+	}
+
+	if resp == nil || !tracedBodies || resp.Body == nil || resp.Body == http.NoBody {
+		finish()
+		return resp, err
+	}
+
+	// the response body is traced while the caller reads it, the span ends when it is closed
+	body := &tracedBody{ReadCloser: resp.Body}
+	body.finish = func() {
+		attrs[tracer.TracerTagResponseBody] = body.traced.String()
+		finish()
+	}
+	resp.Body = body
+
+	return resp, err
+}
+
+// traces checks if a request is traced and if its bodies are traced
+func (t *tracerTransport) traces(req *http.Request) (traced bool, bodies bool) {
+	if t.app.Tracer() == nil {
+		return false, false
+	}
+	if excluder, ok := t.app.Tracer().(capture.Excluder); ok {
+		return true, !excluder.ExcludesBody(req.Method, req.URL.Path)
+	}
+	return true, true
+}
+
+// tracedUrl gets the url of a request that is traced, without the query and the credentials
+func tracedUrl(u *url.URL) string {
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path, RawPath: u.RawPath}).String()
+}
+
+// readTraced reads the part of a body that is traced
+func readTraced(body io.Reader) []byte {
+	traced, _ := io.ReadAll(io.LimitReader(body, maxTracedBody))
+	return traced
+}
+
+// tracedBody body that keeps the traced part while it is read, tracing it when it is closed
+type tracedBody struct {
+	io.ReadCloser
+	traced bytes.Buffer
+	once   sync.Once
+	finish func()
+}
+
+// Read reads the body
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if left := maxTracedBody - b.traced.Len(); left > 0 && n > 0 {
+		b.traced.Write(p[:min(n, left)])
+	}
+	return n, err
+}
+
+// Close closes the body
+func (b *tracedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.finish)
+	return err
+}
+
+// isTimeout checks if an error is a timeout
+func isTimeout(err error) bool {
+	var timeout interface{ Timeout() bool }
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &timeout) && timeout.Timeout())
+}
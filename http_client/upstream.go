@@ -0,0 +1,179 @@
+package http_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	coreErrors "github.com/guilhermealegre/go-clean-arch-core-lib/errors"
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+	errorCodes "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
+	httpClientConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/http_client/config"
+)
+
+// Upstream client of an upstream api
+type Upstream struct {
+	// Name
+	name string
+	// Service Name
+	serviceName string
+	// App
+	app domain.IApp
+	// Configuration
+	config *httpClientConfig.Upstream
+	// Base Url
+	baseUrl *url.URL
+	// Client
+	client *http.Client
+}
+
+// newUpstream creates a new upstream client, with the transports of the retries, circuit breaker, tracer and metrics
+func newUpstream(app domain.IApp, serviceName, name string, config *httpClientConfig.Upstream, metrics *clientMetrics) (*Upstream, error) {
+	baseUrl, err := url.Parse(config.BaseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: time.Duration(config.ConnectTimeoutMilliseconds) * time.Millisecond}
+	var transport http.RoundTripper = &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: dialer.Timeout,
+		MaxIdleConns:        config.MaxIdleConnections,
+		MaxIdleConnsPerHost: config.MaxIdleConnections,
+		IdleConnTimeout:     90 * time.Second,
+		ForceAttemptHTTP2:   true,
+	}
+	transport = &timeoutTransport{timeout: time.Duration(config.TimeoutMilliseconds) * time.Millisecond, next: transport}
+
+	upstreamName := fmt.Sprintf("%s :: %s", serviceName, name)
+	transport = &tracerTransport{app: app, name: upstreamName, metrics: metrics, next: transport}
+
+	var breaker *circuitBreaker
+	if config.CircuitBreaker.Enabled {
+		breaker = newCircuitBreaker(config.CircuitBreaker.FailureThreshold,
+			time.Duration(config.CircuitBreaker.OpenSeconds)*time.Second)
+	}
+	transport = newRetryTransport(name, &config.Retry, breaker, transport)
+	transport = &headersTransport{config: config, next: transport}
+
+	return &Upstream{
+		name:        name,
+		serviceName: upstreamName,
+		app:         app,
+		config:      config,
+		baseUrl:     baseUrl,
+		client:      &http.Client{Transport: transport},
+	}, nil
+}
+
+// Client gets the http client, with the retries, circuit breaker, tracer and metrics
+func (u *Upstream) Client() *http.Client {
+	return u.client
+}
+
+// NewRequest creates a request to a path relative to the base url, which may have a query
+func (u *Upstream) NewRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	ref, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	target := ref
+	if !ref.IsAbs() {
+		target = u.baseUrl.JoinPath(ref.Path)
+		target.RawQuery = ref.RawQuery
+	}
+
+	return http.NewRequestWithContext(ctx, method, target.String(), body)
+}
+
+// Do sends a request, mapping and logging the failures
+func (u *Upstream) Do(req *http.Request) (*http.Response, error) {
+	resp, err := u.client.Do(req)
+	if err != nil {
+		u.log(req.Context(), err)
+		return nil, u.mapError(err)
+	}
+	return resp, nil
+}
+
+// DoJson sends a request with the json of the body, decoding the json of the response.
+// The responses without a 2xx status fail
+func (u *Upstream) DoJson(ctx context.Context, method, path string, body any, response any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := u.NewRequest(ctx, method, path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := u.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		err = errorCodes.ErrorHttpClientStatus().Formats(u.name, resp.StatusCode).
+			SetStatusCode(http.StatusBadGateway)
+		u.log(ctx, err)
+		return err
+	}
+
+	if response == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil && !errors.Is(err, io.EOF) {
+		u.log(ctx, err)
+		return err
+	}
+
+	return nil
+}
+
+// mapError maps the errors of the requests to the error codes
+func (u *Upstream) mapError(err error) error {
+	var errorDetails coreErrors.ErrorDetails
+	switch {
+	case errors.As(err, &errorDetails):
+		return errorDetails
+	case errors.Is(err, context.Canceled):
+		return err
+	case isTimeout(err):
+		return errorCodes.ErrorHttpClientTimeout().Formats(u.name).SetStatusCode(http.StatusGatewayTimeout)
+	default:
+		return errorCodes.ErrorHttpClient().SetStatusCode(http.StatusBadGateway)
+	}
+}
+
+// log logs a failure of a request
+func (u *Upstream) log(ctx context.Context, err error) {
+	if u.app.Logger() != nil && u.app.Logger().Log() != nil {
+		u.app.Logger().Log().Do(err, &domain.LoggerInfo{
+			Context: contextInfra.NewContextFrom(ctx),
+			SubType: domain.HttpClientSubType,
+		})
+	}
+}
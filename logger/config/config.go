@@ -1,6 +1,6 @@
 package config
 
-import "strings"
+import "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
 
 // Config logger configurations
 type Config struct {
@@ -8,12 +8,12 @@ type Config struct {
 	Path string `yaml:"path"`
 	// File
 	File string `yaml:"file"`
-	// Level
+	// Level (trace, debug, info, warn, error, fatal or panic, in any case), every level when empty or invalid
 	Level string `yaml:"level"`
 	// StackTrace
 	StackTrace bool `yaml:"stackTrace"`
 	// Output
-	Output []string `yaml:"output"`
+	Output []string `yaml:"output" validate:"dive,oneof=console file rabbit sqs"`
 	// Rabbitmq Config
 	Rabbitmq *RabbitmqConfig `yaml:"rabbitmq"`
 	// SQS Config
@@ -26,11 +26,11 @@ type Config struct {
 	AdditionalConfig interface{} `yaml:"additionalConfig"`
 }
 
-type BodyExcludeUriList []BodyExcludeUri
-type BodyExcludeUri struct {
-	Method string `yaml:"method"`
-	Uri    string `yaml:"uri"`
-}
+// BodyExcludeUriList uris whose bodies are not logged
+type BodyExcludeUriList = capture.UriList
+
+// BodyExcludeUri uri whose bodies are not logged
+type BodyExcludeUri = capture.Uri
 
 type RabbitmqConfig struct {
 	// Queue
@@ -52,13 +52,3 @@ type MessageAttribute struct {
 	DataType string `yaml:"dataType"`
 	Value    string `yaml:"value"`
 }
-
-func (list BodyExcludeUriList) Contains(method, uri string) bool {
-	for _, i := range list {
-		if strings.EqualFold(i.Method, method) &&
-			strings.EqualFold(i.Uri, uri) {
-			return true
-		}
-	}
-	return false
-}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"io"
 	"net"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
 
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/logger/logging"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/logger/writer"
 
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/config"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
@@ -30,6 +32,8 @@ type Logger struct {
 	log domain.ILogging
 	// Additional Config Type
 	additionalConfigType interface{}
+	// Unsubscribe stops the hot reload of the configurations
+	unsubscribe func()
 	// Started
 	started bool
 }
@@ -39,6 +43,12 @@ const (
 	configFile = "logger.yaml"
 )
 
+// configReloader logging that applies the reloaded configurations
+type configReloader interface {
+	// Reload applies the configurations that are safe to change while running
+	Reload(cfg loggerConfig.Config) error
+}
+
 // New logger service
 func New(app domain.IApp, cfg *loggerConfig.Config, writers ...io.Writer) *Logger {
 	logger := &Logger{
@@ -84,6 +94,11 @@ func (l *Logger) Name() string {
 	return l.name
 }
 
+// DependsOn the writers produce to rabbitmq and sqs
+func (l *Logger) DependsOn() []domain.IService {
+	return []domain.IService{l.app.Rabbitmq(), l.app.SQS()}
+}
+
 // Start starts the logger service
 func (l *Logger) Start() (err error) {
 	if l.config == nil {
@@ -94,6 +109,13 @@ func (l *Logger) Start() (err error) {
 			message.ErrorMessage(l.Name(), err)
 			return err
 		}
+
+		// the configurations loaded from the file are reloaded when it changes
+		if l.unsubscribe, err = config.Subscribe(l.ConfigFile(), l.reload); err != nil {
+			// the logger works without the hot reload
+			message.ErrorMessage(l.Name(), err)
+			err = nil
+		}
 	}
 
 	l.started = true
@@ -101,12 +123,41 @@ func (l *Logger) Start() (err error) {
 	return nil
 }
 
+// reload applies the level and the body exclude uris of the reloaded configurations
+func (l *Logger) reload(cfg *loggerConfig.Config, _ []string) {
+	if reloader, ok := l.Log().(configReloader); ok {
+		if err := reloader.Reload(*cfg); err != nil {
+			message.ErrorMessage(l.Name(), err)
+		}
+	}
+}
+
 // Stop stops the logger service
 func (l *Logger) Stop() error {
+	return l.Shutdown(context.Background())
+}
+
+// ShutdownPhase the log writers are flushed after the traffic and the consumers stop
+func (l *Logger) ShutdownPhase() domain.ShutdownPhase {
+	return domain.ShutdownPhaseFlush
+}
+
+// Shutdown flushes the log writers
+func (l *Logger) Shutdown(_ context.Context) error {
 	if !l.started {
 		return nil
 	}
 	l.started = false
+
+	if l.unsubscribe != nil {
+		l.unsubscribe()
+		l.unsubscribe = nil
+	}
+
+	if flusher, ok := l.log.(writer.Flusher); ok {
+		return flusher.Flush()
+	}
+
 	return nil
 }
 
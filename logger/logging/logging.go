@@ -2,6 +2,8 @@ package logging
 
 import (
 	"encoding/json"
+	"fmt"
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
 	contextDomain "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/context"
 	"runtime/debug"
 	"strings"
@@ -9,10 +11,12 @@ import (
 	"github.com/guilhermealegre/go-clean-arch-core-lib/response"
 
 	"github.com/guilhermealegre/go-clean-arch-core-lib/errors"
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/logger/config"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/logger/writer"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Init initializes the logging
@@ -48,9 +52,77 @@ func (l *Logging) Init(cfg config.Config) domain.ILogging {
 		Timestamp().
 		Logger()
 
+	// an invalid level logs every level, warning about the level
+	level, err := parseLevel(l.config.Level)
+	l.log = l.log.Level(level)
+	if err != nil {
+		l.log.Warn().Err(err).Msg("logging every level")
+	}
+
 	return l
 }
 
+// Reload applies the configurations that are safe to change while running (level and body exclude uris)
+func (l *Logging) Reload(cfg config.Config) error {
+	level, err := parseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.config.Level = cfg.Level
+	l.config.BodyExcludeUris = cfg.BodyExcludeUris
+	l.log = l.log.Level(level)
+
+	return nil
+}
+
+// snapshot gets the logger and the configurations, which may be reloaded concurrently
+func (l *Logging) snapshot() (zerolog.Logger, config.Config) {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+	return l.log, l.config
+}
+
+// parseLevel parses a log level, logging every level when empty
+func parseLevel(level string) (zerolog.Level, error) {
+	if level == "" {
+		return zerolog.TraceLevel, nil
+	}
+
+	parsed, err := zerolog.ParseLevel(strings.ToLower(level))
+	if err != nil || parsed == zerolog.NoLevel {
+		return zerolog.TraceLevel, fmt.Errorf("invalid log level %s", level)
+	}
+
+	return parsed, nil
+}
+
+// ExcludesBody checks if the bodies of an uri are not logged
+func (l *Logging) ExcludesBody(method, uri string) bool {
+	_, cfg := l.snapshot()
+	return excludesBody(cfg, method, uri)
+}
+
+// excludesBody checks if the configurations exclude the bodies of an uri
+func excludesBody(cfg config.Config, method, uri string) bool {
+	return !cfg.Body || cfg.BodyExcludeUris.Contains(method, uri)
+}
+
+// Flush flushes the writers that buffer the messages
+func (l *Logging) Flush() (err error) {
+	for _, w := range l.Writers {
+		if flusher, ok := w.(writer.Flusher); ok {
+			if errFlush := flusher.Flush(); errFlush != nil {
+				err = errFlush
+			}
+		}
+	}
+	return err
+}
+
 // Do log the error
 func (l *Logging) Do(err error, info ...*domain.LoggerInfo) {
 	l.Multi([]error{err}, info...)
@@ -70,6 +142,7 @@ func (l *Logging) Multi(err []error, info ...*domain.LoggerInfo) {
 	var ctx contextDomain.IContext
 	var responseBody string
 	var responseStatusCode int
+	var traceId string
 
 	if len(info) > 0 {
 		if info[0].Context != nil {
@@ -95,11 +168,14 @@ func (l *Logging) Multi(err []error, info ...*domain.LoggerInfo) {
 		if info[0].Response.StatusCode > 0 {
 			responseStatusCode = info[0].Response.StatusCode
 		}
+
+		traceId = info[0].TraceId
 	}
 
-	ev := getErrorEvent(l.log, level, err[0])
+	logger, cfg := l.snapshot()
+	ev := getErrorEvent(logger, level, err[0])
 
-	if l.config.StackTrace {
+	if cfg.StackTrace {
 		ev.Strs("stack", getStack())
 	}
 
@@ -143,14 +219,22 @@ func (l *Logging) Multi(err []error, info ...*domain.LoggerInfo) {
 		Backend:     &domain.Backend{},
 	}
 
+	log.TraceId = traceId
 	if ctx != nil {
+		log.RequestId = ctx.RequestId()
+		if log.TraceId == "" {
+			if spanContext := trace.SpanContextFromContext(ctx.RequestContext()); spanContext.IsValid() {
+				log.TraceId = spanContext.TraceID().String()
+			}
+		}
+
 		if ctx.Request() != nil {
 			log.Backend.Request.Method = ctx.Request().Method
 			log.Backend.Request.Uri = ctx.FullPath()
+			log.Backend.Request.ApiKeyName = ctx.GetString(contextInfra.CtxApiKeyName)
 
-			if l.config.Body &&
-				!l.config.BodyExcludeUris.Contains(log.Backend.Request.Method, log.Backend.Request.Uri) {
-				body := ctx.GetBody()
+			if !excludesBody(cfg, log.Backend.Request.Method, log.Backend.Request.Uri) {
+				body := capture.RequestBody(ctx)
 				if body != nil {
 					log.Backend.Request.Body = string(body)
 				}
@@ -163,8 +247,7 @@ func (l *Logging) Multi(err []error, info ...*domain.LoggerInfo) {
 			}
 			log.Backend.Response.StatusCode = responseStatusCode
 
-			if l.config.Body &&
-				!l.config.BodyExcludeUris.Contains(log.Backend.Request.Method, log.Backend.Request.Uri) {
+			if !excludesBody(cfg, log.Backend.Request.Method, log.Backend.Request.Uri) {
 				log.Backend.Response.Body = responseBody
 			}
 		}
@@ -196,7 +279,8 @@ func (l *Logging) Frontend(error string, level errors.Level, fe *domain.Frontend
 		Frontend:    fe,
 	}
 
-	ev := getErrorByLevel(l.log, level)
+	logger, _ := l.snapshot()
+	ev := getErrorByLevel(logger, level)
 	ev.Interface("log", log)
 	ev.Msg(msg)
 }
@@ -2,6 +2,7 @@ package logging
 
 import (
 	"io"
+	"sync"
 
 	"github.com/rs/zerolog"
 
@@ -37,6 +38,8 @@ type Logging struct {
 	log zerolog.Logger
 	// Default
 	Default Default
+	// Mutex of the configurations that are reloaded
+	mux sync.RWMutex
 }
 
 type Type string
@@ -49,6 +52,8 @@ type Log struct {
 	Error       string           `json:"error"`
 	HostName    string           `json:"hostName"`
 	ClientIp    string           `json:"clientIp"`
+	RequestId   string           `json:"requestId,omitempty"`
+	TraceId     string           `json:"traceId,omitempty"`
 	Backend     *domain.Backend  `json:"backend,omitempty"`
 	Frontend    *domain.Frontend `json:"frontend,omitempty"`
 }
@@ -14,7 +14,7 @@ func NewFallbackWriter(path, fileName string) *FallbackWriter {
 
 // Write write bytes to file
 func (f *FallbackWriter) Write(bytes []byte) (n int, err error) {
-	return f.file.Read(bytes)
+	return f.file.Write(bytes)
 }
 
 // Remove remove file
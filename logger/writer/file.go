@@ -56,6 +56,20 @@ func (f *File) Write(bytes []byte) (n int, err error) {
 	return n, nil
 }
 
+// Flush commits the written bytes of the file to the disk
+func (f *File) Flush() error {
+	file, err := os.OpenFile(f.filePath(), os.O_RDWR, 0777)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return file.Sync()
+}
+
 // open open file
 func (f *File) open() (err error) {
 	if f.file, err = os.Open(f.filePath()); err != nil {
@@ -1,6 +1,8 @@
 package writer
 
 import (
+	"sync"
+
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
@@ -18,6 +20,8 @@ type Rabbit struct {
 	routingKey string
 	// fallback
 	fallback Fallback
+	// Mutex of the fallback file
+	mux sync.Mutex
 }
 
 // NewRabbit creates a new writer to rabbitmq
@@ -48,6 +52,8 @@ func (r *Rabbit) Write(message []byte) (n int, err error) {
 	}
 
 	if r.client == nil || err != nil {
+		r.mux.Lock()
+		defer r.mux.Unlock()
 		if n, err = r.fallback.writer.Write(message); err != nil {
 			return 0, err
 		}
@@ -57,17 +63,33 @@ func (r *Rabbit) Write(message []byte) (n int, err error) {
 	return len(message), nil
 }
 
+// Flush dispatches the messages that were written to the fallback file while the client was unavailable
+func (r *Rabbit) Flush() error {
+	if r.client == nil {
+		// the messages are dispatched by the next start
+		return nil
+	}
+	return r.dispatchFallbackMessages()
+}
+
 // dispatchFallbackMessages dispatches the fallback messages
 func (r *Rabbit) dispatchFallbackMessages() (err error) {
 	if r.client == nil {
 		return errors.ErrorInRabbitmqClientNotFound().Formats("logging (writer)")
 	}
 
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	var lines []string
 	if lines, err = r.fallback.reader.ReadLines(); err != nil {
 		return err
 	}
 
+	if len(lines) == 0 {
+		return nil
+	}
+
 	for _, line := range lines {
 		if err = r.produceMessage(line); err != nil {
 			return err
@@ -1,6 +1,8 @@
 package writer
 
 import (
+	"sync"
+
 	"context"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -22,6 +24,8 @@ type SQS struct {
 	messageAttributes map[string]*sqs.MessageAttributeValue
 	// fallback
 	fallback Fallback
+	// Mutex of the fallback file
+	mux sync.Mutex
 }
 
 // NewSQS creates a new writer to SQS
@@ -58,6 +62,8 @@ func (r *SQS) Write(message []byte) (n int, err error) {
 	}
 
 	if r.client == nil || err != nil {
+		r.mux.Lock()
+		defer r.mux.Unlock()
 		if n, err = r.fallback.writer.Write(message); err != nil {
 			return 0, err
 		}
@@ -67,18 +73,34 @@ func (r *SQS) Write(message []byte) (n int, err error) {
 	return len(message), nil
 }
 
+// Flush dispatches the messages that were written to the fallback file while the client was unavailable
+func (r *SQS) Flush() error {
+	if r.client == nil {
+		// the messages are dispatched by the next start
+		return nil
+	}
+	return r.dispatchFallbackMessages()
+}
+
 // dispatchFallbackMessages dispatches the fallback messages
 func (r *SQS) dispatchFallbackMessages() error {
 	if r.client == nil {
 		return errors.ErrorInSQSClientNotFound().Formats("logging (writer)")
 	}
 
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	var lines []string
 	var err error
 	if lines, err = r.fallback.reader.ReadLines(); err != nil {
 		return err
 	}
 
+	if len(lines) == 0 {
+		return nil
+	}
+
 	for _, line := range lines {
 		if err = r.produceMessage(line); err != nil {
 			return err
@@ -8,3 +8,9 @@ type Fallback struct {
 }
 
 type WriterHandler func(message []byte) error
+
+// Flusher writer that keeps messages to write later, like the fallback files, or that buffers the written bytes
+type Flusher interface {
+	// Flush writes the kept messages
+	Flush() error
+}
@@ -29,6 +29,8 @@ type Meter struct {
 	*prometheus.Exporter
 	// meter
 	metric.Meter
+	// provider
+	provider *sdkMetric.MeterProvider
 	// Started
 	started bool
 }
@@ -83,10 +85,11 @@ func (m *Meter) Start() (err error) {
 		}
 
 		// Creating the MeterProvider and registering it as the global meter provider
-		otel.SetMeterProvider(sdkMetric.NewMeterProvider(
+		m.provider = sdkMetric.NewMeterProvider(
 			sdkMetric.WithReader(exporter),
 			sdkMetric.WithResource(res),
-		))
+		)
+		otel.SetMeterProvider(m.provider)
 
 		m.Exporter = exporter
 		m.Meter = otel.Meter(m.app.Name())
@@ -122,15 +125,29 @@ func (m *Meter) Started() bool {
 
 // Stop stops the meter service
 func (m *Meter) Stop() error {
+	return m.Shutdown(context.Background())
+}
+
+// ShutdownPhase the metrics are flushed after the traffic and the consumers stop
+func (m *Meter) ShutdownPhase() domain.ShutdownPhase {
+	return domain.ShutdownPhaseFlush
+}
+
+// Shutdown collects the pending metrics and stops the provider until the context is done
+func (m *Meter) Shutdown(ctx context.Context) error {
 	if !m.started {
 		return nil
 	}
+	m.started = false
 
-	if m.config.Enabled {
-		return m.Exporter.Shutdown(context.Background())
+	if m.config.Enabled && m.provider != nil {
+		if err := m.provider.ForceFlush(ctx); err != nil {
+			return err
+		}
+		// the provider also shuts down the exporter
+		return m.provider.Shutdown(ctx)
 	}
 
-	m.started = false
 	return nil
 }
 
@@ -7,9 +7,9 @@ type Config struct {
 	// Password
 	Password string `yaml:"password"`
 	// Host
-	Host string `yaml:"host"`
+	Host string `yaml:"host" validate:"required"`
 	// Port
-	Port string `yaml:"port"`
+	Port string `yaml:"port" default:"5672"`
 	// VHost
 	Vhost string `yaml:"vhost"`
 	// Api
@@ -21,7 +21,7 @@ type Config struct {
 	// Queue Config File
 	QueueConfigFile string `yaml:"queueConfigFile"`
 	// Prefetch Count
-	PrefetchCount int `yaml:"prefetchCount"`
+	PrefetchCount int `yaml:"prefetchCount" validate:"min=0"`
 	// Additional Config
 	AdditionalConfig interface{} `yaml:"additionalConfig"`
 }
@@ -1,14 +1,17 @@
 package rabbitmq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"path"
+	"sync"
 
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/config"
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
@@ -34,6 +37,12 @@ type Rabbitmq struct {
 	producerChannel *amqp.Channel
 	// Consumers
 	consumers []domain.IRabbitMQConsumer
+	// Consumer Tags
+	consumerTags []string
+	// Consuming wait group of the message handlers
+	consuming sync.WaitGroup
+	// Drained the consumers were cancelled
+	drained bool
 	// Additional Config Type
 	additionalConfigType interface{}
 	// Started
@@ -96,6 +105,7 @@ func (r *Rabbitmq) Start() (err error) {
 	}
 
 	r.started = true
+	r.drained = false
 
 	return nil
 }
@@ -128,10 +138,85 @@ func (r *Rabbitmq) Connect() {
 
 // Stop stops the rabbitmq service
 func (r *Rabbitmq) Stop() error {
+	return r.Shutdown(context.Background())
+}
+
+// ShutdownPhase the producer is closed at the end, so that the logs of the other phases are still published.
+// The consumers are drained before, in the drain phase
+func (r *Rabbitmq) ShutdownPhase() domain.ShutdownPhase {
+	return domain.ShutdownPhaseClose
+}
+
+// Drain cancels the consumers and waits for the in-flight messages until the context is done
+func (r *Rabbitmq) Drain(ctx context.Context) (err error) {
+	if !r.started || r.drained {
+		return nil
+	}
+	r.drained = true
+
+	// the deliveries channels are closed once the consumers are cancelled
+	for _, tag := range r.consumerTags {
+		if errCancel := r.consumerChannel.Cancel(tag, false); errCancel != nil {
+			err = errCancel
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		r.consuming.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	return err
+}
+
+// Shutdown drains the consumers, if they were not drained yet, and closes the connections
+func (r *Rabbitmq) Shutdown(ctx context.Context) (err error) {
 	if !r.started {
 		return nil
 	}
+
+	err = r.Drain(ctx)
 	r.started = false
+
+	for _, closer := range []interface{ Close() error }{
+		r.consumerChannel,
+		r.producerChannel,
+		r.consumerConnection,
+		r.producerConnection,
+	} {
+		if errClose := closer.Close(); errClose != nil && errClose != amqp.ErrClosed {
+			err = errClose
+		}
+	}
+
+	return err
+}
+
+// HealthCheck checks the state of the connections and channels
+func (r *Rabbitmq) HealthCheck(_ context.Context) error {
+	if r.consumerConnection == nil || r.consumerConnection.IsClosed() {
+		return errors.ErrorRabbitmqConnectionClosed().Formats("consumer connection")
+	}
+
+	if r.producerConnection == nil || r.producerConnection.IsClosed() {
+		return errors.ErrorRabbitmqConnectionClosed().Formats("producer connection")
+	}
+
+	if r.consumerChannel == nil || r.consumerChannel.IsClosed() {
+		return errors.ErrorRabbitmqConnectionClosed().Formats("consumer channel")
+	}
+
+	if r.producerChannel == nil || r.producerChannel.IsClosed() {
+		return errors.ErrorRabbitmqConnectionClosed().Formats("producer channel")
+	}
+
 	return nil
 }
 
@@ -147,6 +232,11 @@ func (r *Rabbitmq) ConfigFile() string {
 
 // Produce produces to the rabbitmq
 func (r *Rabbitmq) Produce(message any, exchange, routingKey string) error {
+	return r.ProduceWithContext(context.Background(), message, exchange, routingKey)
+}
+
+// ProduceWithContext produces to the rabbitmq, with the request id of the context in the headers
+func (r *Rabbitmq) ProduceWithContext(ctx context.Context, message any, exchange, routingKey string) error {
 	bytesMessage, err := json.Marshal(message)
 	if err != nil {
 		return err
@@ -158,6 +248,7 @@ func (r *Rabbitmq) Produce(message any, exchange, routingKey string) error {
 		false,      // Immediate: Return message if it cannot be delivered immediately
 		amqp.Publishing{
 			ContentType: "text/plain",
+			Headers:     requestIdHeaders(ctx),
 			Body:        bytesMessage,
 		},
 	)
@@ -170,9 +261,10 @@ func (r *Rabbitmq) Produce(message any, exchange, routingKey string) error {
 
 // Consume consumes from the rabbitmq
 func (r *Rabbitmq) Consume(app domain.IApp, queue string, handlers map[string]func(amqp.Delivery) bool) {
+	tag := fmt.Sprintf("%s-%s", r.config.Host, queue)
 	messages, err := r.consumerChannel.Consume(
 		queue, // Name of the queue to consume from
-		fmt.Sprintf("%s-%s", r.config.Host, queue), // Consumer name
+		tag,   // Consumer name
 		false, // Auto-acknowledge: Remove messages from the queue once consumed
 		false, // Exclusive: Queue can be accessed by multiple consumers
 		false, // No-local: Do not receive messages published by this connection
@@ -183,13 +275,20 @@ func (r *Rabbitmq) Consume(app domain.IApp, queue string, handlers map[string]fu
 		log.Fatalf("failed to consume messages at queue %s: %s", queue, err)
 	}
 
+	r.consumerTags = append(r.consumerTags, tag)
+
 	// Start a goroutine to process the received messages
-	go r.handleMessages(messages, handlers)
+	r.consuming.Add(1)
+	go func() {
+		defer r.consuming.Done()
+		r.handleMessages(messages, handlers)
+	}()
 }
 
 // handleMessages handles the received messages
 func (r *Rabbitmq) handleMessages(messages <-chan amqp.Delivery, handlers map[string]func(amqp.Delivery) bool) {
 	for m := range messages {
+		m = withRequestId(m)
 		if r.handlerFunc(m, handlers)(m) {
 			err := m.Ack(false)
 			if err != nil {
@@ -228,6 +327,7 @@ func (r *Rabbitmq) recover(message amqp.Delivery) {
 		r.app.Logger().Log().Do(
 			errors.ErrorInRabbitMQConsumer().Formats(message.Exchange, message.RoutingKey, string(message.Body)),
 			&domain.LoggerInfo{
+				Context: contextInfra.NewContextFrom(Context(message)),
 				SubType: domain.RabbitSubType,
 				Msg:     errorMessage,
 				Response: domain.Response{
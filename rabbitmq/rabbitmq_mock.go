@@ -1,6 +1,8 @@
 package rabbitmq
 
 import (
+	"context"
+
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	rabbitmqConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/rabbitmq/config"
 	"github.com/streadway/amqp"
@@ -48,6 +50,11 @@ func (r *RabbitmqMock) Produce(message string, exchange string, routingKey strin
 	return args.Error(0)
 }
 
+func (r *RabbitmqMock) ProduceWithContext(ctx context.Context, message any, exchange string, routingKey string) error {
+	args := r.Called(ctx, message, exchange, routingKey)
+	return args.Error(0)
+}
+
 func (r *RabbitmqMock) Consume(app domain.IApp, queues string, handlers map[string]func(msg amqp.Delivery) bool) {
 	_ = r.Called(app, queues, handlers)
 }
@@ -71,3 +78,9 @@ func (r *RabbitmqMock) Started() bool {
 	args := r.Called()
 	return args.Get(0).(bool)
 }
+
+// HealthCheck checks the service health
+func (r *RabbitmqMock) HealthCheck(ctx context.Context) error {
+	args := r.Called(ctx)
+	return args.Error(0)
+}
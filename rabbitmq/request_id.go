@@ -0,0 +1,48 @@
+package rabbitmq
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+)
+
+// RequestId gets the request id of a message
+func RequestId(message amqp.Delivery) string {
+	id, _ := message.Headers[contextInfra.HeaderRequestId].(string)
+	if !contextInfra.ValidRequestId(id) {
+		return ""
+	}
+	return id
+}
+
+// Context gets a context with the request id of a message, to be used by the handlers
+func Context(message amqp.Delivery) context.Context {
+	return contextInfra.WithRequestId(context.Background(), RequestId(message))
+}
+
+// requestIdHeaders gets the headers with the request id of the context
+func requestIdHeaders(ctx context.Context) amqp.Table {
+	id := contextInfra.RequestId(ctx)
+	if id == "" {
+		return nil
+	}
+	return amqp.Table{contextInfra.HeaderRequestId: id}
+}
+
+// withRequestId sets a new request id in the headers of a message without it
+func withRequestId(message amqp.Delivery) amqp.Delivery {
+	if RequestId(message) != "" {
+		return message
+	}
+
+	headers := make(amqp.Table, len(message.Headers)+1)
+	for key, value := range message.Headers {
+		headers[key] = value
+	}
+	headers[contextInfra.HeaderRequestId] = contextInfra.NewRequestId()
+	message.Headers = headers
+
+	return message
+}
@@ -0,0 +1,15 @@
+package ratelimit
+
+import "time"
+
+const (
+	// TokenBucket algorithm that refills the tokens of the limit over the period, allowing bursts up to the limit
+	TokenBucket = "tokenBucket"
+	// SlidingWindow algorithm that counts the requests of the last period, weighting the previous window
+	SlidingWindow = "slidingWindow"
+
+	// limiterName name of the limiter in the messages
+	limiterName = "Rate Limiter"
+	// cleanupInterval interval between the cleanups of the expired keys of the memory store
+	cleanupInterval = time.Minute
+)
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
+)
+
+// Limiter limits the requests of the keys with a store, falling back to the memory of the instance when the store fails
+type Limiter struct {
+	// Store
+	store IStore
+	// Fallback store
+	fallback IStore
+	// Failing is true while the store fails
+	failing atomic.Bool
+	// Now gets the current time
+	now func() time.Time
+}
+
+// NewLimiter creates a new limiter
+func NewLimiter(store IStore) *Limiter {
+	l := &Limiter{
+		store: store,
+		now:   time.Now,
+	}
+
+	if _, ok := store.(*MemoryStore); !ok {
+		l.fallback = NewMemoryStore()
+	}
+
+	return l
+}
+
+// Allow checks if a request of a key is allowed, counting it when it is
+func (l *Limiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	if !rule.valid() {
+		return Result{Allowed: true}, nil
+	}
+
+	now := l.now()
+	result, err := l.store.Allow(ctx, key, rule, now)
+	if err == nil || l.fallback == nil {
+		if err == nil && l.failing.CompareAndSwap(true, false) {
+			message.Message(limiterName, "store recovered")
+		}
+		return result, err
+	}
+
+	// the failure is only reported once, until the store recovers
+	if l.failing.CompareAndSwap(false, true) {
+		message.ErrorMessage(limiterName, err)
+	}
+
+	return l.fallback.Allow(ctx, key, rule, now)
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Rule limit of the requests of a key
+type Rule struct {
+	// Algorithm (TokenBucket or SlidingWindow)
+	Algorithm string
+	// Limit of requests in the period
+	Limit int
+	// Period
+	Period time.Duration
+}
+
+// Result result of a request
+type Result struct {
+	// Allowed
+	Allowed bool
+	// Limit of requests in the period
+	Limit int
+	// Remaining requests
+	Remaining int
+	// Reset time until the limit is fully available
+	Reset time.Duration
+	// Retry After time until a request is allowed, when it is not allowed
+	RetryAfter time.Duration
+}
+
+// valid checks if the rule limits the requests
+func (r Rule) valid() bool {
+	return r.Limit > 0 && r.Period > 0
+}
+
+// tokenBucketResult gets the result of the token bucket with the tokens after the request
+func (r Rule) tokenBucketResult(allowed bool, tokens float64) Result {
+	// tokens refilled by nanosecond
+	rate := float64(r.Limit) / float64(r.Period)
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     r.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration(math.Ceil((float64(r.Limit) - tokens) / rate)),
+	}
+
+	if !allowed {
+		result.RetryAfter = time.Duration(math.Ceil((1 - tokens) / rate))
+	}
+
+	return result
+}
+
+// slidingWindowResult gets the result of the sliding window with the weighted count after the request
+func (r Rule) slidingWindowResult(allowed bool, previous float64, current float64, elapsed time.Duration) Result {
+	count := previous*(1-float64(elapsed)/float64(r.Period)) + current
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     r.Limit,
+		Remaining: int(math.Max(0, math.Floor(float64(r.Limit)-count))),
+		Reset:     r.Period - elapsed,
+	}
+
+	if !allowed {
+		if current+1 > float64(r.Limit) || previous == 0 {
+			// the previous window does not lower the count enough until the current window ends
+			result.RetryAfter = r.Period - elapsed
+		} else {
+			// time until the weight of the previous window leaves room for a request
+			weight := (float64(r.Limit) - 1 - current) / previous
+			result.RetryAfter = time.Duration((1-weight)*float64(r.Period)) - elapsed
+		}
+		if result.RetryAfter <= 0 {
+			result.RetryAfter = time.Millisecond
+		}
+	}
+
+	return result
+}
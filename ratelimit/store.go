@@ -0,0 +1,231 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
+)
+
+// IStore store of the limits
+type IStore interface {
+	// Allow checks if a request of a key is allowed, counting it when it is
+	Allow(ctx context.Context, key string, rule Rule, now time.Time) (Result, error)
+}
+
+// tokenBucketScript refills and takes a token of a bucket, with the times in milliseconds.
+// The tokens are returned as strings, since redis truncates the numbers
+var tokenBucketScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or limit
+local ts = tonumber(state[2]) or now
+tokens = math.min(limit, tokens + math.max(0, now - ts) * limit / period)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(period))
+return {allowed, tostring(tokens)}
+`)
+
+// slidingWindowScript counts a request in the current window, if the weighted count allows it
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local weight = tonumber(ARGV[2])
+local period = tonumber(ARGV[3])
+local previous = tonumber(redis.call('GET', KEYS[1]) or '0')
+local current = tonumber(redis.call('GET', KEYS[2]) or '0')
+if previous * weight + current + 1 > limit then
+	return {0, previous, current}
+end
+current = redis.call('INCR', KEYS[2])
+redis.call('PEXPIRE', KEYS[2], period * 2)
+return {1, previous, current}
+`)
+
+// RedisStore store that shares the limits between the instances in redis
+type RedisStore struct {
+	// Redis
+	redis domain.IRedis
+	// Prefix of the keys
+	prefix string
+}
+
+// NewRedisStore creates a new redis store
+func NewRedisStore(redis domain.IRedis, prefix string) *RedisStore {
+	return &RedisStore{
+		redis:  redis,
+		prefix: prefix,
+	}
+}
+
+// Allow checks if a request of a key is allowed, counting it when it is
+func (s *RedisStore) Allow(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	client := s.redis.Client()
+
+	if rule.Algorithm == SlidingWindow {
+		window := now.UnixNano() / int64(rule.Period)
+		elapsed := time.Duration(now.UnixNano() % int64(rule.Period))
+		keys := []string{
+			s.prefix + key + ":" + strconv.FormatInt(window-1, 10),
+			s.prefix + key + ":" + strconv.FormatInt(window, 10),
+		}
+
+		values, err := slidingWindowScript.Run(ctx, client, keys,
+			rule.Limit, 1-float64(elapsed)/float64(rule.Period), rule.Period.Milliseconds()).Int64Slice()
+		if err != nil {
+			return Result{}, err
+		}
+		return rule.slidingWindowResult(values[0] == 1, float64(values[1]), float64(values[2]), elapsed), nil
+	}
+
+	values, err := tokenBucketScript.Run(ctx, client, []string{s.prefix + key},
+		rule.Limit, rule.Period.Milliseconds(), now.UnixMilli()).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	allowed, _ := values[0].(int64)
+	text, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return rule.tokenBucketResult(allowed == 1, tokens), nil
+}
+
+// MemoryStore store that keeps the limits in the memory of the instance
+type MemoryStore struct {
+	// Buckets of the token bucket algorithm
+	buckets map[string]*bucket
+	// Windows of the sliding window algorithm
+	windows map[string]*window
+	// Last Cleanup
+	lastCleanup time.Time
+	// Mutex
+	mux sync.Mutex
+}
+
+// bucket state of a token bucket
+type bucket struct {
+	// Tokens
+	tokens float64
+	// Updated At
+	updatedAt time.Time
+	// Expires At
+	expiresAt time.Time
+}
+
+// window state of a sliding window
+type window struct {
+	// Start of the current window
+	start time.Time
+	// Previous count
+	previous float64
+	// Current count
+	current float64
+	// Expires At
+	expiresAt time.Time
+}
+
+// NewMemoryStore creates a new memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		windows: make(map[string]*window),
+	}
+}
+
+// Allow checks if a request of a key is allowed, counting it when it is
+func (s *MemoryStore) Allow(_ context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.cleanup(now)
+
+	if rule.Algorithm == SlidingWindow {
+		return s.allowSlidingWindow(key, rule, now), nil
+	}
+
+	return s.allowTokenBucket(key, rule, now), nil
+}
+
+// allowTokenBucket refills and takes a token of a bucket
+func (s *MemoryStore) allowTokenBucket(key string, rule Rule, now time.Time) Result {
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Limit), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := math.Max(0, float64(now.Sub(b.updatedAt)))
+	b.tokens = math.Min(float64(rule.Limit), b.tokens+elapsed*float64(rule.Limit)/float64(rule.Period))
+	b.updatedAt = now
+	b.expiresAt = now.Add(rule.Period)
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	return rule.tokenBucketResult(allowed, b.tokens)
+}
+
+// allowSlidingWindow counts a request in the current window, if the weighted count allows it
+func (s *MemoryStore) allowSlidingWindow(key string, rule Rule, now time.Time) Result {
+	start := now.Truncate(rule.Period)
+
+	w, ok := s.windows[key]
+	if !ok {
+		w = &window{start: start}
+		s.windows[key] = w
+	}
+
+	switch {
+	case start.Equal(w.start.Add(rule.Period)):
+		w.previous, w.current = w.current, 0
+	case !start.Equal(w.start):
+		w.previous, w.current = 0, 0
+	}
+	w.start = start
+	w.expiresAt = start.Add(2 * rule.Period)
+
+	elapsed := now.Sub(start)
+	allowed := w.previous*(1-float64(elapsed)/float64(rule.Period))+w.current+1 <= float64(rule.Limit)
+	if allowed {
+		w.current++
+	}
+
+	return rule.slidingWindowResult(allowed, w.previous, w.current, elapsed)
+}
+
+// cleanup deletes the expired keys
+func (s *MemoryStore) cleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < cleanupInterval {
+		return
+	}
+	s.lastCleanup = now
+
+	for key, b := range s.buckets {
+		if now.After(b.expiresAt) {
+			delete(s.buckets, key)
+		}
+	}
+
+	for key, w := range s.windows {
+		if now.After(w.expiresAt) {
+			delete(s.windows, key)
+		}
+	}
+}
@@ -7,13 +7,13 @@ func NewConfig() *Config {
 // Config redis configuration
 type Config struct {
 	// Host
-	Host string `yaml:"host"`
+	Host string `yaml:"host" validate:"required"`
 	// Port
-	Port int `yaml:"port"`
+	Port int `yaml:"port" default:"6379" validate:"min=1,max=65535"`
 	// Password
 	Password string `yaml:"password"`
 	// Database
-	Database int `yaml:"database"`
+	Database int `yaml:"database" validate:"min=0"`
 	// Additional Config
 	AdditionalConfig interface{} `yaml:"additionalConfig"`
 }
@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
@@ -105,6 +106,11 @@ func (r *Redis) Client() *redis.Client {
 	return r.client
 }
 
+// HealthCheck sends a PING to the redis server
+func (r *Redis) HealthCheck(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
 // ConfigFile gets the configuration file
 func (r *Redis) ConfigFile() string {
 	return configFile
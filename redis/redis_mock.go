@@ -1,6 +1,8 @@
 package redis
 
 import (
+	"context"
+
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	redisConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/redis/config"
 	"github.com/redis/go-redis/v9"
@@ -62,3 +64,9 @@ func (r *RedisMock) Started() bool {
 	args := r.Called()
 	return args.Get(0).(bool)
 }
+
+// HealthCheck checks the service health
+func (r *RedisMock) HealthCheck(ctx context.Context) error {
+	args := r.Called(ctx)
+	return args.Error(0)
+}
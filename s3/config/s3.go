@@ -5,7 +5,7 @@ type Config struct {
 	// Region
 	Region string `yaml:"region"`
 	// Bucket
-	Bucket string `yaml:"bucket"`
+	Bucket string `yaml:"bucket" validate:"required"`
 	// Role
 	Role string `yaml:"role"`
 	// Additional Config
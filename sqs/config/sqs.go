@@ -3,7 +3,7 @@ package config
 // Config sqs configurations
 type Config struct {
 	// Connections
-	Connections map[string]*Connection `yaml:"connections"`
+	Connections map[string]*Connection `yaml:"connections" validate:"dive,required"`
 	// Additional Config
 	AdditionalConfig interface{} `yaml:"additionalConfig"`
 }
@@ -14,18 +14,18 @@ type Connection struct {
 	// Migrations Disabled
 	MigrationsDisabled bool `yaml:"migrationsDisabled"`
 	// Max Number of Messages
-	MaxNumberOfMessages int64 `yaml:"maxNumberOfMessages"`
+	MaxNumberOfMessages int64 `yaml:"maxNumberOfMessages" default:"10" validate:"min=1,max=10"`
 	// Prefetch Count
-	VisibilityTimeout int64 `yaml:"visibilityTimeOut"`
+	VisibilityTimeout int64 `yaml:"visibilityTimeOut" validate:"min=0,max=43200"`
 	// Wait Time Seconds
-	WaitTimeSeconds int64 `yaml:"waitTimeSeconds"`
+	WaitTimeSeconds int64 `yaml:"waitTimeSeconds" validate:"min=0,max=20"`
 	// Add Environment Prefix Queue
 	AddEnvPrefixQueue bool `yaml:"addEnvPrefixQueue"`
 }
 
 type Credentials struct {
 	// Region
-	Region string `yaml:"region"`
+	Region string `yaml:"region" validate:"required"`
 	// Api
 	Api string `yaml:"api"`
 	// Id Account
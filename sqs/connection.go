@@ -19,6 +19,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/google/uuid"
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/errors"
 )
@@ -47,6 +48,36 @@ func (c *Connection) Connect() error {
 
 	c.WithMiddlewares(tracer.NewTracerMiddleware(c.app))
 
+	c.consumeCtx, c.cancelConsume = context.WithCancel(context.Background())
+
+	return nil
+}
+
+// HealthCheck checks if the consumed queues are reachable
+func (c *Connection) HealthCheck(ctx context.Context) error {
+	if c.consumer == nil {
+		return errors.ErrorInSQSClientNotFound().Formats(c.name)
+	}
+
+	// without consumers there is no known queue, so only the api is checked
+	if len(c.consumers) == 0 {
+		_, err := c.consumer.ListQueuesWithContext(ctx, &sqs.ListQueuesInput{
+			MaxResults: aws.Int64(1),
+		})
+		return err
+	}
+
+	for _, consumer := range c.consumers {
+		maskedQueue := c.maskQueue(c.app.Config().Env, consumer.GetQueue())
+		_, err := c.consumer.GetQueueUrlWithContext(ctx, &sqs.GetQueueUrlInput{
+			QueueName:              aws.String(maskedQueue),
+			QueueOwnerAWSAccountId: aws.String(c.config.Credentials.IdAccount),
+		})
+		if err != nil {
+			return errors.ErrorGettingQueue().Formats(maskedQueue, err)
+		}
+	}
+
 	return nil
 }
 
@@ -54,6 +85,7 @@ func (c *Connection) Connect() error {
 func (c *Connection) Produce(ctx context.Context, queue string, messageAttributes map[string]*sqs.MessageAttributeValue, messages ...string) error {
 	var batch []*sqs.SendMessageBatchRequestEntry
 	maskedQueue := c.maskQueue(c.app.Config().Env, queue)
+	messageAttributes = withRequestId(ctx, messageAttributes)
 
 	queueUrl, err := url.JoinPath(c.config.Credentials.Api, c.config.Credentials.IdAccount, maskedQueue)
 	if err != nil {
@@ -109,12 +141,21 @@ func (c *Connection) Consume(maskedQueue string, consumer domain.ISQSConsumer) {
 		return
 	}
 
+	// the request id of the messages is always received
+	messageAttributeNames := withRequestIdAttribute(consumer.GetMessageAttributeNames())
+
 	for {
-		response, err := c.consumer.ReceiveMessage(
+		// stop consuming, the in-flight messages were already handled
+		if c.consumeCtx.Err() != nil {
+			return
+		}
+
+		response, err := c.consumer.ReceiveMessageWithContext(
+			c.consumeCtx,
 			&sqs.ReceiveMessageInput{
 				QueueUrl:              aws.String(queueUrl),
 				AttributeNames:        consumer.GetAttributeNames(),            // standard attributes of the messages to retrieve when receiving messages
-				MessageAttributeNames: consumer.GetMessageAttributeNames(),     // user-defined key-value pairs attached to individual messages
+				MessageAttributeNames: messageAttributeNames,                   // user-defined key-value pairs attached to individual messages
 				MaxNumberOfMessages:   aws.Int64(c.config.MaxNumberOfMessages), // maximum number of messages to retrieve from the queue in a single API call.
 				VisibilityTimeout:     aws.Int64(c.config.VisibilityTimeout),   // duration for which a message is considered "invisible" after being received by a consumer
 				WaitTimeSeconds:       aws.Int64(c.config.WaitTimeSeconds),     // wait time for a message to become available in the queue if no messages are currently present
@@ -122,6 +163,9 @@ func (c *Connection) Consume(maskedQueue string, consumer domain.ISQSConsumer) {
 		)
 
 		if err != nil {
+			if c.consumeCtx.Err() != nil {
+				return
+			}
 			log.Fatalf("failed to consume messages at queue %s: %s", maskedQueue, err)
 		}
 
@@ -135,6 +179,26 @@ func (c *Connection) Consume(maskedQueue string, consumer domain.ISQSConsumer) {
 	}
 }
 
+// StopConsuming stops receiving new messages and waits for the in-flight ones until the context is done
+func (c *Connection) StopConsuming(ctx context.Context) error {
+	if c.cancelConsume != nil {
+		c.cancelConsume()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.consuming.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // handleMessages handles the messages
 func (c *Connection) handleMessages(ctx context.Context, messages []*sqs.Message, maskedQueue string, handlers map[string]func(ctx context.Context, msg *sqs.Message) bool) {
 	queueUrl, err := url.JoinPath(c.config.Credentials.Api, c.config.Credentials.IdAccount, maskedQueue)
@@ -161,7 +225,7 @@ func (c *Connection) handleMessages(ctx context.Context, messages []*sqs.Message
 			}
 		}
 
-		go f(ctx, messages[i], c.getHandlerFunc(messages[i], maskedQueue, handlers), &wg, &batch)
+		go f(messageContext(ctx, messages[i]), messages[i], c.getHandlerFunc(messages[i], maskedQueue, handlers), &wg, &batch)
 	}
 
 	// Wait for all the consumed messages
@@ -186,7 +250,7 @@ func (c *Connection) getHandlerFunc(message *sqs.Message, maskedQueue string, ha
 		key, ok := message.MessageAttributes["handler"]
 		if ok && *key.StringValue == attribute {
 			return func(ctx context.Context, msg *sqs.Message) bool {
-				defer c.recover(message, maskedQueue)
+				defer c.recover(ctx, message, maskedQueue)
 				return handlerFunc(ctx, msg)
 			}
 		}
@@ -199,7 +263,7 @@ func (c *Connection) getHandlerFunc(message *sqs.Message, maskedQueue string, ha
 }
 
 // recover recovers from a panic during message consumption
-func (c *Connection) recover(message *sqs.Message, maskedQueue string) {
+func (c *Connection) recover(ctx context.Context, message *sqs.Message, maskedQueue string) {
 	if r := recover(); r != nil {
 		data, _ := json.Marshal(r)
 		errorMessage := string(data)
@@ -207,6 +271,7 @@ func (c *Connection) recover(message *sqs.Message, maskedQueue string) {
 		c.app.Logger().Log().Do(
 			errors.ErrorInSQSConsumer().Formats(maskedQueue, message.MessageAttributes, *message.Body),
 			&domain.LoggerInfo{
+				Context: contextInfra.NewContextFrom(ctx),
 				SubType: domain.SQSSubType,
 				Msg:     errorMessage,
 				Response: domain.Response{
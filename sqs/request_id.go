@@ -0,0 +1,75 @@
+package sqs
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+
+	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
+)
+
+const (
+	// allAttributes name that receives every message attribute
+	allAttributes = "All"
+	// stringDataType data type of the string message attributes
+	stringDataType = "String"
+)
+
+// RequestId gets the request id of a message
+func RequestId(message *sqs.Message) string {
+	if message == nil {
+		return ""
+	}
+
+	if attribute, ok := message.MessageAttributes[contextInfra.HeaderRequestId]; ok && attribute != nil &&
+		attribute.StringValue != nil && contextInfra.ValidRequestId(*attribute.StringValue) {
+		return *attribute.StringValue
+	}
+
+	return ""
+}
+
+// withRequestId adds the request id of the context to the message attributes, when they do not have it
+func withRequestId(ctx context.Context, messageAttributes map[string]*sqs.MessageAttributeValue) map[string]*sqs.MessageAttributeValue {
+	id := contextInfra.RequestId(ctx)
+	if id == "" {
+		return messageAttributes
+	}
+
+	if _, ok := messageAttributes[contextInfra.HeaderRequestId]; ok {
+		return messageAttributes
+	}
+
+	// the attributes of the caller are not changed
+	attributes := make(map[string]*sqs.MessageAttributeValue, len(messageAttributes)+1)
+	for name, value := range messageAttributes {
+		attributes[name] = value
+	}
+	attributes[contextInfra.HeaderRequestId] = &sqs.MessageAttributeValue{
+		DataType:    aws.String(stringDataType),
+		StringValue: aws.String(id),
+	}
+
+	return attributes
+}
+
+// withRequestIdAttribute adds the request id to the message attributes to receive
+func withRequestIdAttribute(names []*string) []*string {
+	for _, name := range names {
+		if name != nil && (*name == allAttributes || *name == contextInfra.HeaderRequestId) {
+			return names
+		}
+	}
+
+	return append(append([]*string{}, names...), aws.String(contextInfra.HeaderRequestId))
+}
+
+// messageContext gets the context of a message, with its request id or a new one
+func messageContext(ctx context.Context, message *sqs.Message) context.Context {
+	id := RequestId(message)
+	if id == "" {
+		id = contextInfra.NewRequestId()
+	}
+	return contextInfra.WithRequestId(ctx, id)
+}
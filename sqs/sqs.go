@@ -1,6 +1,7 @@
 package sqs
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain/message"
@@ -67,6 +68,11 @@ func (s *SQS) WithConsumer(consumer domain.ISQSConsumer) domain.ISQS {
 	return s
 }
 
+// DependsOn the consumers are traced as soon as they start
+func (s *SQS) DependsOn() []domain.IService {
+	return []domain.IService{s.app.Tracer()}
+}
+
 // Start starts the sqs service
 func (s *SQS) Start() (err error) {
 	if s.config == nil {
@@ -92,7 +98,11 @@ func (s *SQS) Start() (err error) {
 				return err
 			}
 
-			go conn.Consume(maskedQueue, consumer)
+			conn.consuming.Add(1)
+			go func(conn *Connection, maskedQueue string, consumer domain.ISQSConsumer) {
+				defer conn.consuming.Done()
+				conn.Consume(maskedQueue, consumer)
+			}(conn, maskedQueue, consumer)
 		}
 	}
 
@@ -103,10 +113,44 @@ func (s *SQS) Start() (err error) {
 
 // Stop stops the sqs service
 func (s *SQS) Stop() error {
+	return s.Shutdown(context.Background())
+}
+
+// ShutdownPhase the consumers are drained after the traffic stops
+func (s *SQS) ShutdownPhase() domain.ShutdownPhase {
+	return domain.ShutdownPhaseDrain
+}
+
+// Shutdown stops consuming and waits for the in-flight messages until the context is done
+func (s *SQS) Shutdown(ctx context.Context) (err error) {
 	if !s.started {
 		return nil
 	}
 	s.started = false
+
+	// every connection stops receiving before waiting for the in-flight messages
+	for _, conn := range s.connections {
+		if conn.cancelConsume != nil {
+			conn.cancelConsume()
+		}
+	}
+
+	for _, conn := range s.connections {
+		if errConn := conn.StopConsuming(ctx); errConn != nil {
+			err = errConn
+		}
+	}
+
+	return err
+}
+
+// HealthCheck checks if the queues of every connection are reachable
+func (s *SQS) HealthCheck(ctx context.Context) error {
+	for _, conn := range s.connections {
+		if err := conn.HealthCheck(ctx); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
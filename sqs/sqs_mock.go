@@ -1,6 +1,8 @@
 package sqs
 
 import (
+	"context"
+
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
 	sqsConfig "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/sqs/config"
 	"github.com/stretchr/testify/mock"
@@ -71,3 +73,9 @@ func (s *SqsMock) Started() bool {
 	args := s.Called()
 	return args.Get(0).(bool)
 }
+
+// HealthCheck checks the service health
+func (s *SqsMock) HealthCheck(ctx context.Context) error {
+	args := s.Called(ctx)
+	return args.Error(0)
+}
@@ -1,6 +1,9 @@
 package sqs
 
 import (
+	"context"
+	"sync"
+
 	"github.com/aws/aws-sdk-go/service/sqs"
 	sqsSdk "github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/guilhermealegre/go-clean-arch-infrastructure-lib/domain"
@@ -43,6 +46,12 @@ type Connection struct {
 	consumers []domain.ISQSConsumer
 	// Middlewares
 	middlewares []middlewares.Middleware
+	// Consume Context cancelled to stop consuming
+	consumeCtx context.Context
+	// Cancel Consume
+	cancelConsume context.CancelFunc
+	// Consuming wait group of the consumer loops
+	consuming sync.WaitGroup
 }
 
 type Migration struct {
@@ -30,14 +30,15 @@ func (sm *StateMachine) Load(filePath string) error {
 	}
 	defer file.Close()
 
-	//var test any
-	viper.SetConfigFile(filePath)
+	// each state machine has its own viper instance
+	v := viper.New()
+	v.SetConfigFile(filePath)
 
-	if err = viper.ReadInConfig(); err != nil {
+	if err = v.ReadInConfig(); err != nil {
 		return err
 	}
 
-	if err = viper.Unmarshal(&sm); err != nil {
+	if err = v.Unmarshal(&sm); err != nil {
 		return err
 	}
 
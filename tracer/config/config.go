@@ -1,6 +1,6 @@
 package config
 
-import "strings"
+import "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/capture"
 
 // Config configurations for the tracer
 type Config struct {
@@ -9,25 +9,15 @@ type Config struct {
 	// Log
 	Log bool `yaml:"log"`
 	// CollectorHostPort
-	CollectorHostPort string `yaml:"collectorHostPort"`
+	CollectorHostPort string `yaml:"collectorHostPort" validate:"required_if=Enabled true"`
 	// Sensitive Uris
 	SensitiveUris SensitiveUriList `yaml:"sensitiveUris"`
 	// Additional Config
 	AdditionalConfig interface{} `yaml:"additionalConfig"`
 }
 
-type SensitiveUriList []SensitiveUri
-type SensitiveUri struct {
-	Method string `yaml:"method"`
-	Uri    string `yaml:"uri"`
-}
+// SensitiveUriList uris whose bodies and params are not traced
+type SensitiveUriList = capture.UriList
 
-func (list SensitiveUriList) Contains(method, uri string) bool {
-	for _, i := range list {
-		if strings.EqualFold(i.Method, method) &&
-			strings.EqualFold(i.Uri, uri) {
-			return true
-		}
-	}
-	return false
-}
+// SensitiveUri uri whose bodies and params are not traced
+type SensitiveUri = capture.Uri
@@ -17,4 +17,10 @@ const (
 	TracerTagBucket            = "bucket"
 	TracerTagStatusCode        = "otel.status_code"
 	TracerTagStatusDescription = "otel.status_description"
+	TracerTagApiKeyName        = "api_key.name"
+	TracerTagRequestId         = "request.id"
+	TracerTagUpstream          = "http.upstream"
+	TracerTagHttpUrl           = "http.url"
+	TracerTagHttpStatus        = "http.status_code"
+	TracerTagRetry             = "http.retry"
 )
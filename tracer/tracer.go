@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	contextInfra "github.com/guilhermealegre/go-clean-arch-infrastructure-lib/context"
 	"go.opentelemetry.io/otel/trace/noop"
@@ -38,8 +39,14 @@ type Tracer struct {
 	trace.Tracer
 	// exporter
 	*otlptrace.Exporter
+	// provider
+	provider *sdkTrace.TracerProvider
 	// Additional Config Type
 	additionalConfigType interface{}
+	// Unsubscribe stops the hot reload of the configurations
+	unsubscribe func()
+	// Mutex of the configurations that are reloaded
+	mux sync.RWMutex
 	// Started
 	started bool
 }
@@ -78,6 +85,13 @@ func (t *Tracer) Start() (err error) {
 			message.ErrorMessage(t.Name(), err)
 			return err
 		}
+
+		// the configurations loaded from the file are reloaded when it changes
+		if t.unsubscribe, err = config.Subscribe(t.ConfigFile(), t.reload); err != nil {
+			// the tracer works without the hot reload
+			message.ErrorMessage(t.Name(), err)
+			err = nil
+		}
 	}
 
 	if t.config.Enabled {
@@ -92,15 +106,16 @@ func (t *Tracer) Start() (err error) {
 
 		// Creating the TracerProvider using a batch span processor to aggregate spans before export
 		// and registering it as the global tracer provider
-		otel.SetTracerProvider(sdkTrace.NewTracerProvider(
+		t.provider = sdkTrace.NewTracerProvider(
 			sdkTrace.WithSampler(sdkTrace.AlwaysSample()),
 			sdkTrace.WithResource(resource.NewWithAttributes(
 				semconv.SchemaURL,
 				semconv.ServiceNameKey.String(t.app.Name()),
 				attribute.String("service", t.app.Name()),
 			)),
-			sdkTrace.WithSpanProcessor(sdkTrace.NewBatchSpanProcessor(exporter))),
+			sdkTrace.WithSpanProcessor(sdkTrace.NewBatchSpanProcessor(exporter)),
 		)
+		otel.SetTracerProvider(t.provider)
 
 		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
@@ -123,15 +138,34 @@ func (t *Tracer) Started() bool {
 
 // Stop stops the tracer service
 func (t *Tracer) Stop() error {
+	return t.Shutdown(context.Background())
+}
+
+// ShutdownPhase the spans are flushed after the traffic and the consumers stop
+func (t *Tracer) ShutdownPhase() domain.ShutdownPhase {
+	return domain.ShutdownPhaseFlush
+}
+
+// Shutdown exports the pending spans and stops the provider until the context is done
+func (t *Tracer) Shutdown(ctx context.Context) error {
 	if !t.started {
 		return nil
 	}
+	t.started = false
 
-	if t.config.Enabled {
-		return t.Exporter.Shutdown(context.Background())
+	if t.unsubscribe != nil {
+		t.unsubscribe()
+		t.unsubscribe = nil
+	}
+
+	if t.config.Enabled && t.provider != nil {
+		if err := t.provider.ForceFlush(ctx); err != nil {
+			return err
+		}
+		// the provider also shuts down the exporter
+		return t.provider.Shutdown(ctx)
 	}
 
-	t.started = false
 	return nil
 }
 
@@ -151,6 +185,25 @@ func (t *Tracer) WithAdditionalConfigType(obj interface{}) domain.ITracer {
 	return t
 }
 
+// reload applies the sensitive uris of the reloaded configurations
+func (t *Tracer) reload(cfg *tracerConfig.Config, _ []string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.config.SensitiveUris = cfg.SensitiveUris
+}
+
+// isSensitive checks if the body and the params of an uri can not be traced
+func (t *Tracer) isSensitive(method, uri string) bool {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	return t.config.SensitiveUris.Contains(method, uri)
+}
+
+// ExcludesBody checks if the bodies of an uri are not traced
+func (t *Tracer) ExcludesBody(method, uri string) bool {
+	return t.config == nil || !t.config.Enabled || t.isSensitive(method, uri)
+}
+
 // Trace traces data
 func (t *Tracer) Trace(ctx context.Context, spanName string, data map[string]any, err error) {
 	_, span := t.Tracer.Start(ctx, spanName)
@@ -175,11 +228,15 @@ func (t *Tracer) setAttributesWithValidation(ctx context.Context, span trace.Spa
 		span.SetAttributes(attribute.String(TracerTagStatusCode, "OK"))
 	}
 
+	if id := contextInfra.RequestId(ctx); id != "" {
+		span.SetAttributes(attribute.String(TracerTagRequestId, id))
+	}
+
 	for key, value := range data {
 		if key == TracerTagParams || key == TracerTagRequestBody || key == TracerTagResponseBody {
 			if ctx != nil {
 				if ctx.Value(contextInfra.CtxMethod) != nil && ctx.Value(contextInfra.CtxPath) != nil {
-					if !t.config.SensitiveUris.Contains(
+					if !t.isSensitive(
 						fmt.Sprintf("%s", ctx.Value(contextInfra.CtxMethod)),
 						fmt.Sprintf("%s", ctx.Value(contextInfra.CtxPath))) {
 						span.SetAttributes(getAttribute(key, value))